@@ -14,6 +14,7 @@
 package jsonpointer
 
 import (
+	"context"
 	"reflect"
 )
 
@@ -28,6 +29,13 @@ type Assigner interface {
 	AssignByJSONPointer(ptr *Pointer, value interface{}) error
 }
 
+// ContextAssigner is an Assigner which is provided the context.Context of the
+// operation. If a type implements both ContextAssigner and Assigner,
+// AssignByJSONPointerContext is preferred.
+type ContextAssigner interface {
+	AssignByJSONPointerContext(ctx context.Context, ptr *Pointer, value interface{}) error
+}
+
 // Assign performs an assignment of value to the target dst specified by the
 // JSON Pointer ptr. Assign traverses dst recursively, resolving the path of
 // the JSON Pointer. If a type in the path implements Resolver, it will attempt
@@ -38,13 +46,23 @@ type Assigner interface {
 // If a type in the path implements Assigner, AssignByJSONPointer will be called
 // with the updated value pertinent to that path.
 //
-func Assign(dst interface{}, ptr Pointer, value interface{}) error {
+// The behavior of Assign can be configured with opts. See Options.
+func Assign(dst interface{}, ptr Pointer, value interface{}, opts ...Option) error {
+	return AssignContext(context.Background(), dst, ptr, value, opts...)
+}
+
+// AssignContext is Assign with a context.Context which is passed to any
+// ContextResolver or ContextAssigner in the path. ctx is checked for
+// cancellation between tokens; if it is done, the returned error wraps
+// ctx.Err().
+func AssignContext(ctx context.Context, dst interface{}, ptr Pointer, value interface{}, opts ...Option) error {
 	if value == nil {
-		return Delete(dst, ptr)
+		return DeleteContext(ctx, dst, ptr, opts...)
 	}
 	dv := reflect.ValueOf(dst)
-	s := newState(ptr, Assigning)
+	s := newState(ptr, Assigning, newOptions(opts))
 	defer s.Release()
+	s.ctx = ctx
 	if err := ptr.Validate(); err != nil {
 		return newError(err, *s, dv.Type())
 	}
@@ -54,6 +72,9 @@ func Assign(dst interface{}, ptr Pointer, value interface{}) error {
 	cpy := dv
 	dv = dv.Elem()
 	if dv.Kind() == reflect.Ptr && dv.IsNil() {
+		if s.opts.NoCreate && !ptr.IsRoot() {
+			return newError(ErrUnreachable, *s, dv.Type())
+		}
 		dv = reflect.New(dv.Type().Elem())
 	}
 	dp := reflect.New(dv.Type())
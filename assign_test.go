@@ -215,3 +215,62 @@ func TestAssignJSON(t *testing.T) {
 		fmt.Println("--- PASS")
 	}
 }
+
+func TestAssignValueKinds(t *testing.T) {
+	assert := require.New(t)
+
+	// values which are neither assignable nor raw JSON are rejected
+	var r Root
+	err := jsonpointer.Assign(&r, "/nested/int", "str")
+	assert.ErrorIs(err, jsonpointer.ErrNotAssignable)
+	assert.Zero(r.Nested.Int)
+
+	// values assigned to raw JSON are encoded
+	err = jsonpointer.Assign(&r, "/nested/json", map[string]int{"a": 1})
+	assert.NoError(err)
+	assert.Equal(`{"a":1}`, string(r.Nested.JSON))
+}
+
+func TestAssignJSONPreservesNumbers(t *testing.T) {
+	assert := require.New(t)
+	doc := []byte(`{"id":9007199254740993,"price":1.10,"big":1e400,"items":[{"id":18446744073709551615}]}`)
+
+	err := jsonpointer.Assign(&doc, "/name", "widget")
+	assert.NoError(err)
+	assert.Equal(`{"big":1e400,"id":9007199254740993,"items":[{"id":18446744073709551615}],"name":"widget","price":1.10}`, string(doc))
+
+	err = jsonpointer.Assign(&doc, "/items/-", json.RawMessage(`{"id":9223372036854775807}`))
+	assert.NoError(err)
+	assert.Contains(string(doc), `"id":9223372036854775807`)
+
+	r := Root{Nested: Nested{JSON: json.RawMessage(`{"n":123456789012345678}`)}}
+	err = jsonpointer.Assign(&r, "/nested/json/m", 1)
+	assert.NoError(err)
+	assert.JSONEq(`{"n":123456789012345678,"m":1}`, string(r.Nested.JSON))
+	assert.Contains(string(r.Nested.JSON), `123456789012345678`)
+
+	// resolution continues to decode numbers as float64
+	var v interface{}
+	err = jsonpointer.Resolve(doc, "/price", &v)
+	assert.NoError(err)
+	assert.Equal(1.1, v)
+}
+
+func TestAssignRawJSONValue(t *testing.T) {
+	assert := require.New(t)
+
+	// raw JSON assigned to Go values is decoded as it would be by
+	// json.Unmarshal; numbers are only kept as json.Number within raw JSON
+	var v struct {
+		Any  map[string]interface{}            `json:"any"`
+		Maps map[string]map[string]interface{} `json:"maps"`
+	}
+	err := jsonpointer.Assign(&v, "/any", json.RawMessage(`{"n":1}`))
+	assert.NoError(err)
+	assert.Equal(map[string]interface{}{"n": float64(1)}, v.Any)
+
+	v.Maps = map[string]map[string]interface{}{}
+	err = jsonpointer.Assign(&v, "/maps/x", []byte(`{"n":1.5}`))
+	assert.NoError(err)
+	assert.Equal(map[string]interface{}{"n": 1.5}, v.Maps["x"])
+}
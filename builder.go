@@ -0,0 +1,196 @@
+// Copyright 2022 Chance Dinkins
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+//
+// The License can be found in the LICENSE file.
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonpointer
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+var builderPool sync.Pool
+
+// Builder is used to efficiently build a Pointer from typed segments. The
+// zero value is ready to use.
+//
+// Builders obtained from NewBuilder are pooled and should be returned with
+// Release once the Pointer has been built.
+//
+// Example:
+//
+//	b := jsonpointer.NewBuilder()
+//	defer b.Release()
+//	ptr := b.AppendKey("items").AppendIndex(3).AppendKey("name").Pointer() // => "/items/3/name"
+type Builder struct {
+	buf []byte
+}
+
+// NewBuilder returns an empty Builder from the pool.
+func NewBuilder() *Builder {
+	if v := builderPool.Get(); v != nil {
+		return v.(*Builder)
+	}
+	return &Builder{}
+}
+
+// Release resets b and returns it to the pool. b must not be used after
+// calling Release.
+func (b *Builder) Release() {
+	b.Reset()
+	builderPool.Put(b)
+}
+
+// Reset resets b to the Root, retaining the underlying buffer.
+func (b *Builder) Reset() {
+	b.buf = b.buf[:0]
+}
+
+// AppendKey encodes key and appends it as a token.
+func (b *Builder) AppendKey(key string) *Builder {
+	b.buf = append(b.buf, '/')
+	if strings.IndexAny(key, "~/") == -1 {
+		b.buf = append(b.buf, key...)
+		return b
+	}
+	for i := 0; i < len(key); i++ {
+		switch key[i] {
+		case '~':
+			b.buf = append(b.buf, '~', '0')
+		case '/':
+			b.buf = append(b.buf, '~', '1')
+		default:
+			b.buf = append(b.buf, key[i])
+		}
+	}
+	return b
+}
+
+// AppendIndex appends i as a token.
+//
+// Note: negative values of i result in tokens which are not array indexes.
+func (b *Builder) AppendIndex(i int) *Builder {
+	b.buf = append(b.buf, '/')
+	b.buf = strconv.AppendInt(b.buf, int64(i), 10)
+	return b
+}
+
+// AppendToken appends t as is.
+//
+// Note: t is not encoded. Use AppendKey to encode and append a key.
+func (b *Builder) AppendToken(t Token) *Builder {
+	b.buf = append(b.buf, '/')
+	b.buf = append(b.buf, t...)
+	return b
+}
+
+// AppendPointer appends each of the tokens of p.
+func (b *Builder) AppendPointer(p Pointer) *Builder {
+	b.buf = append(b.buf, p...)
+	return b
+}
+
+// Pointer returns the Pointer built so far.
+func (b *Builder) Pointer() Pointer {
+	return Pointer(b.buf)
+}
+
+// String returns the Pointer built so far as a string.
+func (b *Builder) String() string {
+	return string(b.buf)
+}
+
+// NewFrom encodes and returns segments as a Pointer. Each segment is
+// appended according to its type:
+//
+// - string: encoded and appended as a key
+//
+// - Token: appended as is
+//
+// - Pointer: each of its tokens are appended
+//
+// - int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+// appended as an index
+//
+// - encoding.TextMarshaler: the text is encoded and appended as a key
+//
+// - fmt.Stringer: the string is encoded and appended as a key
+//
+// Any other type, aside from those with an underlying string or integer
+// kind, results in an error wrapping ErrUnsupportedSegment.
+//
+// Examples:
+//
+//	jsonpointer.NewFrom("items", 3, "name") => "/items/3/name"
+//	jsonpointer.NewFrom("a/b", jsonpointer.Token("c~1d")) => "/a~1b/c~1d"
+func NewFrom(segments ...interface{}) (Pointer, error) {
+	b := NewBuilder()
+	defer b.Release()
+	for _, seg := range segments {
+		if err := b.appendSegment(seg); err != nil {
+			return Root, err
+		}
+	}
+	return b.Pointer(), nil
+}
+
+func (b *Builder) appendSegment(seg interface{}) error {
+	switch v := seg.(type) {
+	case string:
+		b.AppendKey(v)
+	case Token:
+		b.AppendToken(v)
+	case Pointer:
+		b.AppendPointer(v)
+	case int:
+		b.AppendIndex(v)
+	case int8, int16, int32, int64:
+		b.appendInt(reflect.ValueOf(v).Int())
+	case uint, uint8, uint16, uint32, uint64:
+		b.appendUint(reflect.ValueOf(v).Uint())
+	case encoding.TextMarshaler:
+		text, err := v.MarshalText()
+		if err != nil {
+			return err
+		}
+		b.AppendKey(string(text))
+	case fmt.Stringer:
+		b.AppendKey(v.String())
+	default:
+		rv := reflect.ValueOf(seg)
+		switch rv.Kind() {
+		case reflect.String:
+			b.AppendKey(rv.String())
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			b.appendInt(rv.Int())
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			b.appendUint(rv.Uint())
+		default:
+			return fmt.Errorf("%w: %T", ErrUnsupportedSegment, seg)
+		}
+	}
+	return nil
+}
+
+func (b *Builder) appendInt(i int64) {
+	b.buf = append(b.buf, '/')
+	b.buf = strconv.AppendInt(b.buf, i, 10)
+}
+
+func (b *Builder) appendUint(u uint64) {
+	b.buf = append(b.buf, '/')
+	b.buf = strconv.AppendUint(b.buf, u, 10)
+}
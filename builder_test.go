@@ -0,0 +1,91 @@
+// Copyright 2022 Chance Dinkins
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+//
+// The License can be found in the LICENSE file.
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonpointer_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/chanced/jsonpointer"
+	"github.com/stretchr/testify/require"
+)
+
+type namedKey string
+
+type namedIndex uint16
+
+func TestBuilder(t *testing.T) {
+	assert := require.New(t)
+	b := jsonpointer.NewBuilder()
+	defer b.Release()
+
+	p := b.AppendKey("items").AppendIndex(3).AppendKey("name").Pointer()
+	assert.Equal(jsonpointer.Pointer("/items/3/name"), p)
+
+	b.Reset()
+	assert.Equal(jsonpointer.Root, b.Pointer())
+
+	b.AppendKey("a/b").AppendKey("c~d").AppendToken("e~1f").AppendPointer("/g/h")
+	assert.Equal("/a~1b/c~0d/e~1f/g/h", b.String())
+
+	b.Reset()
+	b.AppendKey("")
+	assert.Equal(jsonpointer.Pointer("/"), b.Pointer())
+
+	var zero jsonpointer.Builder
+	assert.Equal(jsonpointer.Pointer("/-1"), zero.AppendIndex(-1).Pointer())
+}
+
+func TestBuilderAllocs(t *testing.T) {
+	assert := require.New(t)
+	b := jsonpointer.NewBuilder()
+	defer b.Release()
+	b.AppendKey("items").AppendIndex(300).AppendKey("name~/")
+	allocs := testing.AllocsPerRun(100, func() {
+		b.Reset()
+		b.AppendKey("items").AppendIndex(300).AppendKey("name~/")
+	})
+	assert.Zero(allocs)
+}
+
+func TestNewFrom(t *testing.T) {
+	assert := require.New(t)
+	tests := []struct {
+		segments []interface{}
+		expected jsonpointer.Pointer
+	}{
+		{nil, ""},
+		{[]interface{}{"items", 3, "name"}, "/items/3/name"},
+		{[]interface{}{"a/b", jsonpointer.Token("c~1d")}, "/a~1b/c~1d"},
+		{[]interface{}{jsonpointer.Pointer("/a/b"), "c"}, "/a/b/c"},
+		{[]interface{}{int8(1), int16(2), int32(3), int64(4)}, "/1/2/3/4"},
+		{[]interface{}{uint(1), uint8(2), uint16(3), uint32(4), uint64(5)}, "/1/2/3/4/5"},
+		{[]interface{}{namedKey("x/y"), namedIndex(7)}, "/x~1y/7"},
+		{[]interface{}{strval("str~val")}, "/str~0val"},
+		{[]interface{}{Key{key: "custom/key"}}, "/custom~1key"},
+		{[]interface{}{time.Date(2022, 9, 10, 0, 0, 0, 0, time.UTC)}, "/2022-09-10T00:00:00Z"},
+		{[]interface{}{""}, "/"},
+	}
+	for _, test := range tests {
+		p, err := jsonpointer.NewFrom(test.segments...)
+		assert.NoError(err)
+		assert.Equal(test.expected, p)
+	}
+
+	_, err := jsonpointer.NewFrom("foo", 1.5)
+	assert.ErrorIs(err, jsonpointer.ErrUnsupportedSegment)
+
+	_, err = jsonpointer.NewFrom(struct{}{})
+	assert.ErrorIs(err, jsonpointer.ErrUnsupportedSegment)
+}
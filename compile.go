@@ -0,0 +1,444 @@
+// Copyright 2022 Chance Dinkins
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+//
+// The License can be found in the LICENSE file.
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonpointer
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"strconv"
+)
+
+var dynamicInterfaces = []reflect.Type{
+	typeResolver,
+	typeContextResolver,
+	typeAssigner,
+	reflect.TypeOf((*ContextAssigner)(nil)).Elem(),
+	reflect.TypeOf((*Deleter)(nil)).Elem(),
+	reflect.TypeOf((*ContextDeleter)(nil)).Elem(),
+}
+
+// Compiled is a Pointer which has been validated against a Go type and
+// compiled into a plan of struct field indexes, map keys, and slice and array
+// indexes. Resolving, assigning, or deleting with a Compiled avoids parsing
+// tokens and looking up struct fields on each call.
+//
+// Portions of the path which can not be determined by the type alone, such
+// as values held by interfaces, raw JSON, or types which implement Resolver,
+// Assigner, or Deleter, are handled as Resolve, Assign, and Delete would.
+//
+// A Compiled is safe for concurrent use.
+type Compiled struct {
+	ptr   Pointer
+	typ   reflect.Type
+	opts  Options
+	steps []step
+	// rest is the remainder of ptr which is handled dynamically.
+	rest Pointer
+	// last is the index of the step of the final token of ptr or len(steps)
+	// if the final token is within rest.
+	last int
+}
+
+type stepKind uint8
+
+const (
+	stepDeref stepKind = iota
+	stepField
+	stepMapKey
+	stepIndex
+)
+
+type step struct {
+	kind stepKind
+	// at is the remainder of the pointer prior to the step.
+	at       Pointer
+	typ      reflect.Type
+	index    []int
+	readonly bool
+	key      reflect.Value
+	// i is the index of a slice or array; -1 refers to the element after
+	// the last element of a slice ("-").
+	i int
+}
+
+// Compile compiles ptr for values of typ. An error is returned if ptr is not
+// valid or can not address a value of typ, such as when a struct field does
+// not exist or an array index is out of range.
+//
+// The Compiled uses opts, as the package-level functions would, for every
+// operation.
+func Compile(ptr Pointer, typ reflect.Type, opts ...Option) (*Compiled, error) {
+	return compile(ptr, typ, newOptions(opts))
+}
+
+// CompileFor is Compile for the type T.
+//
+//	c, err := jsonpointer.CompileFor[Request]("/user/name")
+func CompileFor[T any](ptr Pointer, opts ...Option) (*Compiled, error) {
+	return Compile(ptr, reflect.TypeOf((*T)(nil)).Elem(), opts...)
+}
+
+// Compile is Compile with the Options of e.
+func (e *Engine) Compile(ptr Pointer, typ reflect.Type, opts ...Option) (*Compiled, error) {
+	return compile(ptr, typ, e.opts.apply(opts))
+}
+
+func compile(ptr Pointer, typ reflect.Type, opts Options) (*Compiled, error) {
+	if typ == nil {
+		panic("jsonpointer: Compile called with a nil reflect.Type")
+	}
+	s := newState(ptr, Resolving, opts)
+	defer s.Release()
+	if err := ptr.Validate(); err != nil {
+		return nil, newError(err, *s, typ)
+	}
+	c := &Compiled{ptr: ptr, typ: typ, opts: opts, last: -1}
+	t := typ
+	for !s.current.IsRoot() {
+		for t.Kind() == reflect.Ptr && !isDynamicType(t) {
+			c.steps = append(c.steps, step{kind: stepDeref, at: s.current, typ: t})
+			t = t.Elem()
+		}
+		if isDynamicType(t) {
+			break
+		}
+		rest, tok, _ := s.current.Next()
+		st := step{at: s.current, typ: t}
+		var next reflect.Type
+		switch t.Kind() {
+		case reflect.Struct:
+			f, err := s.structField(t, tok)
+			if err != nil {
+				return nil, err
+			}
+			st.kind = stepField
+			st.index = f.index
+			st.readonly = f.readonly
+			next = t.FieldByIndex(f.index).Type
+		case reflect.Map:
+			kv, err := s.mapKey(reflect.Zero(t), tok)
+			if err != nil {
+				return nil, err
+			}
+			st.kind = stepMapKey
+			st.key = kv
+			next = t.Elem()
+		case reflect.Slice:
+			st.kind = stepIndex
+			st.i = -1
+			if tok != "-" {
+				i, err := s.index(tok, math.MaxInt32)
+				if err != nil {
+					if errors.Is(err, strconv.ErrSyntax) {
+						err = ErrMalformedIndex
+					}
+					return nil, newError(err, *s, t)
+				}
+				st.i = i
+			}
+			next = t.Elem()
+		case reflect.Array:
+			if tok == "-" && !opts.StrictDash {
+				// "-" refers to the first trailing zero value of the array,
+				// which depends on the value
+				break
+			}
+			i, err := s.arrayIndex(reflect.Zero(t), tok)
+			if err != nil {
+				return nil, err
+			}
+			st.kind = stepIndex
+			st.i = i
+			next = t.Elem()
+		default:
+			return nil, newError(ErrUnreachable, *s, t)
+		}
+		if next == nil {
+			break
+		}
+		c.steps = append(c.steps, st)
+		if rest.IsRoot() {
+			c.last = len(c.steps) - 1
+		}
+		s.current = rest
+		t = next
+	}
+	c.rest = s.current
+	if c.last < 0 {
+		c.last = len(c.steps)
+	}
+	return c, nil
+}
+
+// Pointer returns the Pointer c was compiled from.
+func (c *Compiled) Pointer() Pointer {
+	return c.ptr
+}
+
+// Type returns the reflect.Type c was compiled for.
+func (c *Compiled) Type() reflect.Type {
+	return c.typ
+}
+
+// Resolve is Resolve for the compiled pointer. src should be a value of, or a
+// pointer to, the compiled type; otherwise Resolve is used.
+func (c *Compiled) Resolve(src interface{}, dst interface{}) error {
+	v := reflect.ValueOf(src)
+	if v.IsValid() && v.Type() != c.typ && v.Kind() == reflect.Ptr && v.Type().Elem() == c.typ && !v.IsNil() {
+		v = v.Elem()
+	}
+	if !v.IsValid() || v.Type() != c.typ {
+		return Resolve(src, c.ptr, dst, WithOptions(c.opts))
+	}
+	dv := reflect.ValueOf(dst)
+	s := newState(c.ptr, Resolving, c.opts)
+	defer s.Release()
+	s.scan = true
+	if !isWriter(dv) && (dv.Kind() != reflect.Ptr || dv.IsNil()) {
+		return newError(ErrNonPointer, *s, dv.Type())
+	}
+	var err error
+	for i := range c.steps {
+		st := &c.steps[i]
+		if v, err = c.resolveStep(s, st, v); err != nil {
+			return err
+		}
+	}
+	s.current = c.rest
+	if v, err = s.resolve(v); err != nil {
+		return err
+	}
+	return s.setResolved(dv, v)
+}
+
+func (c *Compiled) resolveStep(s *state, st *step, v reflect.Value) (reflect.Value, error) {
+	s.current = st.at
+	switch st.kind {
+	case stepDeref:
+		if v.IsNil() {
+			return v, newError(ErrUnreachable, *s, st.typ)
+		}
+		return v.Elem(), nil
+	case stepField:
+		fv, err := v.FieldByIndexErr(st.index)
+		if err != nil {
+			return fv, newError(ErrUnreachable, *s, st.typ)
+		}
+		v = fv
+	case stepMapKey:
+		v = v.MapIndex(st.key)
+		if !v.IsValid() {
+			return v, newError(ErrNotFound, *s, st.typ)
+		}
+	case stepIndex:
+		i := st.i
+		if i < 0 || i >= v.Len() {
+			if i < 0 {
+				i = v.Len()
+			}
+			return reflect.Value{}, newError(&indexError{
+				err:      ErrOutOfRange,
+				maxIndex: v.Len() - 1,
+				index:    i,
+			}, *s, st.typ)
+		}
+		v = v.Index(i)
+	}
+	if isNil(v) {
+		// reported as Resolve would, beyond the token of the step
+		s.current, _, _ = st.at.Next()
+		return v, newError(ErrUnreachable, *s, st.typ)
+	}
+	return v, nil
+}
+
+// Assign is Assign for the compiled pointer. dst should be a pointer to the
+// compiled type; otherwise Assign is used.
+//
+// The value dst points to is assigned in place. A struct field or an element
+// of a slice or array referenced by the final token is set directly, while map
+// entries and elements appended to slices are assigned as Assign would.
+func (c *Compiled) Assign(dst interface{}, value interface{}) error {
+	if value == nil {
+		return c.Delete(dst)
+	}
+	dv := reflect.ValueOf(dst)
+	if dv.Kind() != reflect.Ptr || dv.IsNil() || dv.Type().Elem() != c.typ {
+		return Assign(dst, c.ptr, value, WithOptions(c.opts))
+	}
+	s := newState(c.ptr, Assigning, c.opts)
+	defer s.Release()
+	val := reflect.ValueOf(value)
+	n := c.last
+	if n < len(c.steps) && c.steps[n].kind != stepMapKey {
+		n++
+	}
+	v, j, _, err := c.walk(s, dv.Elem(), n, true)
+	if err != nil {
+		return err
+	}
+	if j > c.last {
+		s.current = c.rest
+		_, err = s.assignValue(v.Addr(), val)
+		return err
+	}
+	s.current = c.at(j)
+	res, err := s.assign(v.Addr(), val)
+	if err != nil {
+		return err
+	}
+	v.Set(res.Elem())
+	return nil
+}
+
+// Delete is Delete for the compiled pointer. dst should be a pointer to the
+// compiled type; otherwise Delete is used.
+//
+// The value dst points to is modified in place. A struct field or an element
+// of an array referenced by the final token is set to its zero value and a map
+// entry is removed directly, while elements of slices are removed as Delete
+// would.
+func (c *Compiled) Delete(dst interface{}) error {
+	dv := reflect.ValueOf(dst)
+	if dv.Kind() != reflect.Ptr || dv.IsNil() || dv.Type().Elem() != c.typ {
+		return Delete(dst, c.ptr, WithOptions(c.opts))
+	}
+	s := newState(c.ptr, Deleting, c.opts)
+	defer s.Release()
+	n := c.last
+	if n < len(c.steps) && (c.steps[n].kind == stepField || c.steps[n].kind == stepIndex && c.steps[n].typ.Kind() == reflect.Array) {
+		n++
+	}
+	v, j, ok, err := c.walk(s, dv.Elem(), n, false)
+	if err != nil || !ok {
+		// nothing to delete if the path is unreachable
+		return err
+	}
+	switch {
+	case j > c.last:
+		s.current = c.rest
+		return s.deleteValue(v.Addr())
+	case j == c.last && j < len(c.steps) && c.steps[j].kind == stepMapKey:
+		v.SetMapIndex(c.steps[j].key, reflect.Value{})
+		return nil
+	}
+	s.current = c.at(j)
+	res, err := s.delete(v.Addr())
+	if err != nil {
+		return err
+	}
+	v.Set(res.Elem())
+	return nil
+}
+
+// at returns the remainder of the pointer prior to the step at index j.
+func (c *Compiled) at(j int) Pointer {
+	if j < len(c.steps) {
+		return c.steps[j].at
+	}
+	return c.rest
+}
+
+// walk follows the first n steps of c through the addressable value v,
+// stopping early at map keys and slice indexes which are out of range. It
+// returns the value reached and the index of the step at which it stopped,
+// which is n if all n steps were followed. The remainder of the pointer is to
+// be assigned or deleted from the value.
+//
+// Nil pointers, maps, and slices along the way are allocated if create is
+// true (and Options.NoCreate is not set); otherwise ok is false.
+func (c *Compiled) walk(s *state, v reflect.Value, n int, create bool) (reflect.Value, int, bool, error) {
+	// prev is the remainder of the pointer prior to the token which resolved
+	// to v
+	prev := c.ptr
+	alloc := func(st *step) (bool, error) {
+		if !isNil(v) {
+			return true, nil
+		}
+		if !create {
+			return false, nil
+		}
+		if s.opts.NoCreate {
+			s.current = prev
+			return false, newError(ErrUnreachable, *s, st.typ)
+		}
+		switch v.Kind() {
+		case reflect.Ptr:
+			v.Set(reflect.New(v.Type().Elem()))
+		case reflect.Map:
+			v.Set(reflect.MakeMap(v.Type()))
+		case reflect.Slice:
+			v.Set(reflect.MakeSlice(v.Type(), 0, 1))
+		}
+		return true, nil
+	}
+	for j := 0; j < n; j++ {
+		st := &c.steps[j]
+		switch st.kind {
+		case stepDeref:
+			if ok, err := alloc(st); !ok {
+				return v, j, false, err
+			}
+			v = v.Elem()
+			continue
+		case stepField:
+			if st.readonly {
+				s.current = st.at
+				return v, j, false, &fieldError{
+					ptrError: *newError(ErrReadOnly, *s, st.typ),
+					field:    st.typ.FieldByIndex(st.index),
+				}
+			}
+			for i, x := range st.index {
+				if i > 0 && v.Kind() == reflect.Ptr {
+					if ok, err := alloc(st); !ok {
+						return v, j, false, err
+					}
+					v = v.Elem()
+				}
+				v = v.Field(x)
+			}
+		case stepMapKey:
+			if ok, err := alloc(st); !ok {
+				return v, j, false, err
+			}
+			return v, j, true, nil
+		case stepIndex:
+			if v.Kind() == reflect.Slice {
+				if ok, err := alloc(st); !ok {
+					return v, j, false, err
+				}
+			}
+			if st.i < 0 || st.i >= v.Len() {
+				return v, j, true, nil
+			}
+			v = v.Index(st.i)
+		}
+		prev = st.at
+	}
+	return v, n, true, nil
+}
+
+func isDynamicType(t reflect.Type) bool {
+	if t.Kind() == reflect.Interface || t.AssignableTo(typeByteSlice) {
+		return true
+	}
+	for _, it := range dynamicInterfaces {
+		if t.Implements(it) || (t.Kind() != reflect.Ptr && reflect.PtrTo(t).Implements(it)) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,313 @@
+// Copyright 2022 Chance Dinkins
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+//
+// The License can be found in the LICENSE file.
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonpointer_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/chanced/jsonpointer"
+	"github.com/stretchr/testify/require"
+)
+
+func compileRoot() Root {
+	return Root{
+		Nested: Nested{
+			Str:       "strval",
+			Inline:    Inline{InlineStr: "inline value"},
+			Nested:    &Nested{Str: "deeply nested value"},
+			Embedded:  Embedded{Value: "embedded value"},
+			IntSlice:  []int{1, 2, 3},
+			StrArray:  [3]string{"foo", "bar", ""},
+			IntMap:    map[int]int{1: 10},
+			CustomMap: map[Key]string{{"foo"}: "bar"},
+			EntryMap:  map[string]*Entry{"foo": {Name: "bar"}},
+			JSON:      json.RawMessage(`{"obj":{"value":"raw"}}`),
+		},
+	}
+}
+
+func TestCompileResolve(t *testing.T) {
+	assert := require.New(t)
+	r := compileRoot()
+
+	tests := []jsonpointer.Pointer{
+		"",
+		"/nested",
+		"/nested/str",
+		"/nested/inline",
+		"/nested/nested/str",
+		"/nested/embedded/value",
+		"/nested/intslice/2",
+		"/nested/intslice/3",
+		"/nested/intslice/-",
+		"/nested/strarray/1",
+		"/nested/strarray/-",
+		"/nested/intmap/1",
+		"/nested/intmap/2",
+		"/nested/custommap/foo",
+		"/nested/entrymap/foo/name",
+		"/nested/entrymap/bar/name",
+		"/nested/empty/str",
+		"/nested/nestedptr",
+		"/nestedptr/str",
+		"/nested/json/obj/value",
+		"/nested/yield/value",
+	}
+
+	for i, ptr := range tests {
+		fmt.Printf("=== RUN TestCompileResolve #%d, pointer %s\n", i, ptr)
+		var expected interface{}
+		expectedErr := jsonpointer.Resolve(r, ptr, &expected)
+
+		c, err := jsonpointer.Compile(ptr, reflect.TypeOf(r))
+		if err != nil {
+			assert.Error(expectedErr, "test %d", i)
+			assert.Equal(errorChain(expectedErr), errorChain(err), "test %d", i)
+			fmt.Println("--- PASS")
+			continue
+		}
+		var v interface{}
+		err = c.Resolve(r, &v)
+		assert.Equal(expected, v, "test %d", i)
+		if expectedErr != nil {
+			assert.Equal(errorChain(expectedErr), errorChain(err), "test %d", i)
+			e1, _ := jsonpointer.AsError(expectedErr)
+			e2, ok := jsonpointer.AsError(err)
+			assert.True(ok, "test %d", i)
+			assert.Equal(e1.CurrentJSONPointer(), e2.CurrentJSONPointer(), "test %d", i)
+		} else {
+			assert.NoError(err, "test %d", i)
+		}
+
+		// a pointer to the compiled type is also accepted
+		v = nil
+		err = c.Resolve(&r, &v)
+		assert.Equal(expected, v, "test %d", i)
+		assert.Equal(expectedErr == nil, err == nil, "test %d", i)
+		fmt.Println("--- PASS")
+	}
+}
+
+// errorChain returns the sentinel errors of err which are relevant to
+// comparing compiled and dynamic results.
+func errorChain(err error) []bool {
+	var chain []bool
+	for _, target := range []error{
+		jsonpointer.ErrNotFound,
+		jsonpointer.ErrUnreachable,
+		jsonpointer.ErrOutOfRange,
+		jsonpointer.ErrMalformedIndex,
+		jsonpointer.ErrUnexportedField,
+	} {
+		chain = append(chain, errors.Is(err, target))
+	}
+	return chain
+}
+
+func TestCompileErrors(t *testing.T) {
+	assert := require.New(t)
+	typ := reflect.TypeOf(Root{})
+
+	tests := []struct {
+		ptr jsonpointer.Pointer
+		err error
+	}{
+		{"/missing", jsonpointer.ErrNotFound},
+		{"/nested/private", jsonpointer.ErrUnexportedField},
+		{"/nested/intslice/x", jsonpointer.ErrMalformedIndex},
+		{"/nested/strarray/3", jsonpointer.ErrOutOfRange},
+		{"/nested/str/x", jsonpointer.ErrUnreachable},
+		{"/nested/intmap/x", nil},
+		{"nested", jsonpointer.ErrMalformedStart},
+	}
+	for i, test := range tests {
+		fmt.Printf("=== RUN TestCompileErrors #%d, pointer %s\n", i, test.ptr)
+		c, err := jsonpointer.Compile(test.ptr, typ)
+		assert.Error(err, "test %d", i)
+		assert.Nil(c, "test %d", i)
+		if test.err != nil {
+			assert.ErrorIs(err, test.err, "test %d", i)
+		} else {
+			assert.True(jsonpointer.IsKeyError(err), "test %d", i)
+		}
+		fmt.Println("--- PASS")
+	}
+
+	_, err := jsonpointer.Compile("/nested/intslice/01", typ, jsonpointer.WithStrictIndex(true))
+	assert.ErrorIs(err, jsonpointer.ErrNonCanonicalIndex)
+	_, err = jsonpointer.Compile("/nested/strarray/-", typ, jsonpointer.WithStrictDash(true))
+	assert.ErrorIs(err, jsonpointer.ErrOutOfRange)
+	_, err = jsonpointer.Compile("/Nested/Str", typ, jsonpointer.WithCaseSensitive(true))
+	assert.ErrorIs(err, jsonpointer.ErrNotFound)
+	_, err = jsonpointer.Compile("/Nested/Str", typ)
+	assert.NoError(err)
+}
+
+func TestCompileAssign(t *testing.T) {
+	assert := require.New(t)
+	c, err := jsonpointer.CompileFor[Root]("/nestedptr/nested/str")
+	assert.NoError(err)
+	assert.Equal(reflect.TypeOf(Root{}), c.Type())
+	assert.Equal(jsonpointer.Pointer("/nestedptr/nested/str"), c.Pointer())
+
+	var r Root
+	err = c.Assign(&r, "value")
+	assert.NoError(err)
+	assert.Equal("value", r.NestedPtr.Nested.Str)
+
+	c, err = jsonpointer.CompileFor[Root]("/nested/entrymap/foo/name")
+	assert.NoError(err)
+	err = c.Assign(&r, "bar")
+	assert.NoError(err)
+	assert.Equal("bar", r.Nested.EntryMap["foo"].Name)
+
+	c, err = jsonpointer.CompileFor[Root]("/nested/intslice/-")
+	assert.NoError(err)
+	assert.NoError(c.Assign(&r, 1))
+	assert.NoError(c.Assign(&r, 2))
+	assert.Equal([]int{1, 2}, r.Nested.IntSlice)
+
+	c, err = jsonpointer.CompileFor[Root]("/nested/strarray/2")
+	assert.NoError(err)
+	assert.NoError(c.Assign(&r, "baz"))
+	assert.Equal("baz", r.Nested.StrArray[2])
+
+	c, err = jsonpointer.CompileFor[Root]("/nested/json/obj/value")
+	assert.NoError(err)
+	assert.NoError(c.Assign(&r, "raw"))
+	var jv JSONValue
+	assert.NoError(json.Unmarshal(r.Nested.JSON, &jv))
+	assert.Equal("raw", jv.Obj.Value)
+
+	c, err = jsonpointer.CompileFor[Root]("/nested/str")
+	assert.NoError(err)
+	err = c.Assign(&r, 1)
+	assert.ErrorIs(err, jsonpointer.ErrNotAssignable)
+
+	// values of other types are assigned dynamically
+	m := map[string]interface{}{}
+	assert.NoError(c.Assign(&m, "x"))
+	assert.Equal(map[string]interface{}{"nested": map[string]interface{}{"str": "x"}}, m)
+
+	c, err = jsonpointer.CompileFor[Root]("/nestedptr/str", jsonpointer.WithCreateContainers(false))
+	assert.NoError(err)
+	r = Root{}
+	err = c.Assign(&r, "x")
+	assert.ErrorIs(err, jsonpointer.ErrUnreachable)
+	assert.Nil(r.NestedPtr)
+}
+
+func TestCompileReadOnly(t *testing.T) {
+	assert := require.New(t)
+	v := pointerTagged{ID: "id", Meta: map[string]string{"k": "v"}}
+
+	for _, ptr := range []jsonpointer.Pointer{"/id", "/meta/k"} {
+		c, err := jsonpointer.CompileFor[pointerTagged](ptr)
+		assert.NoError(err)
+		assert.ErrorIs(c.Assign(&v, "x"), jsonpointer.ErrReadOnly)
+		assert.ErrorIs(c.Delete(&v), jsonpointer.ErrReadOnly)
+		var s string
+		assert.NoError(c.Resolve(v, &s))
+	}
+	assert.Equal("id", v.ID)
+	assert.Equal(map[string]string{"k": "v"}, v.Meta)
+}
+
+func TestCompileDelete(t *testing.T) {
+	assert := require.New(t)
+	r := compileRoot()
+
+	c, err := jsonpointer.CompileFor[Root]("/nested/entrymap/foo")
+	assert.NoError(err)
+	assert.NoError(c.Delete(&r))
+	assert.NotContains(r.Nested.EntryMap, "foo")
+
+	c, err = jsonpointer.CompileFor[Root]("/nested/intslice/0")
+	assert.NoError(err)
+	assert.NoError(c.Delete(&r))
+	assert.Equal([]int{2, 3}, r.Nested.IntSlice)
+
+	c, err = jsonpointer.CompileFor[Root]("/nested/nested/str")
+	assert.NoError(err)
+	assert.NoError(c.Delete(&r))
+	assert.Equal("", r.Nested.Nested.Str)
+
+	c, err = jsonpointer.CompileFor[Root]("/nested/strarray/0")
+	assert.NoError(err)
+	assert.NoError(c.Delete(&r))
+	assert.Equal([3]string{"", "bar", ""}, r.Nested.StrArray)
+
+	c, err = jsonpointer.CompileFor[Root]("/nested/intmap/1")
+	assert.NoError(err)
+	assert.NoError(c.Delete(&r))
+	assert.Empty(r.Nested.IntMap)
+
+	// deleting beneath a nil pointer is a no-op
+	c, err = jsonpointer.CompileFor[Root]("/nestedptr/str")
+	assert.NoError(err)
+	assert.NoError(c.Delete(&r))
+	assert.Nil(r.NestedPtr)
+}
+
+func BenchmarkResolve(b *testing.B) {
+	r := compileRoot()
+	var s string
+	for i := 0; i < b.N; i++ {
+		if err := jsonpointer.Resolve(r, "/nested/nested/str", &s); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkCompiledResolve(b *testing.B) {
+	r := compileRoot()
+	c, err := jsonpointer.CompileFor[Root]("/nested/nested/str")
+	if err != nil {
+		b.Fatal(err)
+	}
+	var s string
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := c.Resolve(r, &s); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkAssign(b *testing.B) {
+	r := compileRoot()
+	for i := 0; i < b.N; i++ {
+		if err := jsonpointer.Assign(&r, "/nested/nested/str", "value"); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkCompiledAssign(b *testing.B) {
+	r := compileRoot()
+	c, err := jsonpointer.CompileFor[Root]("/nested/nested/str")
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := c.Assign(&r, "value"); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,175 @@
+// Copyright 2022 Chance Dinkins
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+//
+// The License can be found in the LICENSE file.
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonpointer_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/chanced/jsonpointer"
+	"github.com/stretchr/testify/require"
+)
+
+type ctxKey struct{}
+
+// ctxStore implements both the plain and the context-aware interfaces so that
+// tests can assert which was invoked.
+type ctxStore struct {
+	Values map[string]string
+	calls  []string
+}
+
+func (cs *ctxStore) ResolveJSONPointer(ptr *jsonpointer.Pointer, op jsonpointer.Operation) (interface{}, error) {
+	cs.calls = append(cs.calls, "ResolveJSONPointer")
+	return nil, jsonpointer.YieldOperation
+}
+
+func (cs *ctxStore) ResolveJSONPointerContext(ctx context.Context, ptr *jsonpointer.Pointer, op jsonpointer.Operation) (interface{}, error) {
+	cs.calls = append(cs.calls, "ResolveJSONPointerContext:"+ctx.Value(ctxKey{}).(string))
+	t, ok := ptr.NextToken()
+	if !ok {
+		return nil, jsonpointer.YieldOperation
+	}
+	v, ok := cs.Values[t.String()]
+	if !ok {
+		if op.IsAssigning() {
+			return "", nil
+		}
+		return nil, jsonpointer.ErrNotFound
+	}
+	return v, nil
+}
+
+func (cs *ctxStore) AssignByJSONPointer(ptr *jsonpointer.Pointer, value interface{}) error {
+	cs.calls = append(cs.calls, "AssignByJSONPointer")
+	return jsonpointer.YieldOperation
+}
+
+func (cs *ctxStore) AssignByJSONPointerContext(ctx context.Context, ptr *jsonpointer.Pointer, value interface{}) error {
+	cs.calls = append(cs.calls, "AssignByJSONPointerContext:"+ctx.Value(ctxKey{}).(string))
+	t, _ := ptr.NextToken()
+	cs.Values[t.String()] = value.(string)
+	return nil
+}
+
+func (cs *ctxStore) DeleteByJSONPointer(ptr *jsonpointer.Pointer) error {
+	cs.calls = append(cs.calls, "DeleteByJSONPointer")
+	return jsonpointer.YieldOperation
+}
+
+func (cs *ctxStore) DeleteByJSONPointerContext(ctx context.Context, ptr *jsonpointer.Pointer) error {
+	cs.calls = append(cs.calls, "DeleteByJSONPointerContext:"+ctx.Value(ctxKey{}).(string))
+	t, _ := ptr.NextToken()
+	delete(cs.Values, t.String())
+	return nil
+}
+
+type ctxRoot struct {
+	Store *ctxStore `json:"store"`
+}
+
+func TestResolveContext(t *testing.T) {
+	assert := require.New(t)
+	ctx := context.WithValue(context.Background(), ctxKey{}, "req-1")
+	r := ctxRoot{Store: &ctxStore{Values: map[string]string{"foo": "bar"}}}
+
+	var v interface{}
+	err := jsonpointer.ResolveContext(ctx, r, "/store/foo", &v)
+	assert.NoError(err)
+	assert.Equal("bar", v)
+	assert.Equal([]string{"ResolveJSONPointerContext:req-1"}, r.Store.calls)
+
+	r.Store.calls = nil
+	err = jsonpointer.ResolveContext(ctx, r, "/store/missing", &v)
+	assert.ErrorIs(err, jsonpointer.ErrNotFound)
+}
+
+func TestAssignContext(t *testing.T) {
+	assert := require.New(t)
+	ctx := context.WithValue(context.Background(), ctxKey{}, "req-2")
+	r := ctxRoot{Store: &ctxStore{Values: map[string]string{}}}
+
+	err := jsonpointer.AssignContext(ctx, &r, "/store/foo", "bar")
+	assert.NoError(err)
+	assert.Equal("bar", r.Store.Values["foo"])
+	assert.Contains(r.Store.calls, "AssignByJSONPointerContext:req-2")
+	assert.NotContains(r.Store.calls, "AssignByJSONPointer")
+}
+
+func TestDeleteContext(t *testing.T) {
+	assert := require.New(t)
+	ctx := context.WithValue(context.Background(), ctxKey{}, "req-3")
+	r := ctxRoot{Store: &ctxStore{Values: map[string]string{"foo": "bar"}}}
+
+	err := jsonpointer.DeleteContext(ctx, &r, "/store/foo")
+	assert.NoError(err)
+	assert.NotContains(r.Store.Values, "foo")
+	assert.Contains(r.Store.calls, "DeleteByJSONPointerContext:req-3")
+	assert.NotContains(r.Store.calls, "DeleteByJSONPointer")
+}
+
+func TestContextCanceled(t *testing.T) {
+	assert := require.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	r := Root{Nested: Nested{Str: "strval"}}
+	var v interface{}
+	err := jsonpointer.ResolveContext(ctx, r, "/nested/str", &v)
+	assert.ErrorIs(err, context.Canceled)
+	assert.Nil(v)
+
+	err = jsonpointer.AssignContext(ctx, &r, "/nested/str", "x")
+	assert.ErrorIs(err, context.Canceled)
+	assert.Equal("strval", r.Nested.Str)
+
+	err = jsonpointer.DeleteContext(ctx, &r, "/nested/str")
+	assert.ErrorIs(err, context.Canceled)
+	assert.Equal("strval", r.Nested.Str)
+
+	// the root pointer has no tokens to traverse
+	err = jsonpointer.ResolveContext(ctx, r, jsonpointer.Root, &v)
+	assert.NoError(err)
+}
+
+// deadlineResolver cancels the operation's context part way through
+// resolution to verify that cancellation is checked between tokens.
+type deadlineResolver struct {
+	cancel context.CancelFunc
+	Next   map[string]interface{}
+}
+
+func (dr deadlineResolver) ResolveJSONPointerContext(ctx context.Context, ptr *jsonpointer.Pointer, op jsonpointer.Operation) (interface{}, error) {
+	dr.cancel()
+	return dr.Next, nil
+}
+
+func TestContextCanceledBetweenTokens(t *testing.T) {
+	assert := require.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	src := deadlineResolver{
+		cancel: cancel,
+		Next:   map[string]interface{}{"b": map[string]interface{}{"c": 1}},
+	}
+	var v interface{}
+	err := jsonpointer.ResolveContext(ctx, src, "/a/b/c", &v)
+	assert.ErrorIs(err, context.Canceled)
+	assert.Nil(v)
+	if perr, ok := jsonpointer.AsError(err); ok {
+		assert.Equal(jsonpointer.Pointer("/b/c"), perr.CurrentJSONPointer())
+	} else {
+		assert.Fail("expected jsonpointer.Error")
+	}
+}
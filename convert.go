@@ -0,0 +1,264 @@
+// Copyright 2022 Chance Dinkins
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+//
+// The License can be found in the LICENSE file.
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonpointer
+
+import (
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+var (
+	typeDuration   = reflect.TypeOf(time.Duration(0))
+	typeJSONNumber = reflect.TypeOf(json.Number(""))
+)
+
+// convert converts v to typ so that it can be set to the destination of
+// Resolve. The following conversions are performed:
+//
+// - numbers are converted to other numeric types so long as the value is
+// retained; ErrOverflow is returned if it is out of range. Floating-point
+// values converted to float32 are rounded to the nearest value, as they are
+// by encoding/json, while integers beyond its precision are rejected.
+//
+// - json.Number is parsed into numeric types and numbers are formatted as
+// json.Number.
+//
+// - strings are parsed into time.Duration with time.ParseDuration.
+//
+// - strings are unmarshaled into types which implement
+// encoding.TextUnmarshaler.
+//
+// - strings and bools are converted to named types of the same kind.
+//
+// - generic JSON objects and arrays (map[string]interface{} and
+// []interface{}) are encoded and then decoded into typ with the Codec of s.
+func (s *state) convert(v reflect.Value, typ reflect.Type) (reflect.Value, error) {
+	switch {
+	case v.Type() == typeJSONNumber && isNumberKind(typ.Kind()):
+		return s.convertJSONNumber(v, typ)
+	case typ == typeJSONNumber && isNumberKind(v.Kind()):
+		return s.formatJSONNumber(v, typ)
+	case isNumberKind(v.Kind()) && isNumberKind(typ.Kind()):
+		return s.convertNumber(v, typ)
+	case v.Kind() == reflect.String && typ == typeDuration:
+		d, err := time.ParseDuration(v.String())
+		if err != nil {
+			return reflect.Value{}, s.conversionError(err, v, typ)
+		}
+		return reflect.ValueOf(d), nil
+	case v.Kind() == reflect.String && reflect.PtrTo(typ).Implements(typeTextUnmarshaler):
+		nv := reflect.New(typ)
+		if err := nv.Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(v.String())); err != nil {
+			return reflect.Value{}, s.conversionError(err, v, typ)
+		}
+		return nv.Elem(), nil
+	case v.Kind() == typ.Kind() && (v.Kind() == reflect.String || v.Kind() == reflect.Bool):
+		return v.Convert(typ), nil
+	case v.Type() == typeAnyMap || v.Type() == typeAnySlice:
+		return s.decodeInto(v, typ)
+	}
+	return reflect.Value{}, newValueError(ErrNotAssignable, *s, typ, v.Type())
+}
+
+func (s *state) convertJSONNumber(v reflect.Value, typ reflect.Type) (reflect.Value, error) {
+	str := v.String()
+	switch {
+	case isIntKind(typ.Kind()):
+		if i, err := strconv.ParseInt(str, 10, 64); err == nil {
+			return s.convertNumber(reflect.ValueOf(i), typ)
+		} else if errors.Is(err, strconv.ErrRange) {
+			return reflect.Value{}, newValueError(ErrOverflow, *s, typ, v.Type())
+		}
+	case isUintKind(typ.Kind()):
+		if u, err := strconv.ParseUint(str, 10, 64); err == nil {
+			return s.convertNumber(reflect.ValueOf(u), typ)
+		} else if errors.Is(err, strconv.ErrRange) && (len(str) == 0 || str[0] != '-') {
+			return reflect.Value{}, newValueError(ErrOverflow, *s, typ, v.Type())
+		}
+	default:
+		// integers are converted as such so that those beyond the precision
+		// of typ are rejected
+		if i, err := strconv.ParseInt(str, 10, 64); err == nil {
+			return s.convertNumber(reflect.ValueOf(i), typ)
+		}
+	}
+	// the number may be in exponent or decimal form (e.g. 1e3 or 1.0)
+	f, err := strconv.ParseFloat(str, 64)
+	if err != nil {
+		if errors.Is(err, strconv.ErrRange) {
+			return reflect.Value{}, newValueError(ErrOverflow, *s, typ, v.Type())
+		}
+		return reflect.Value{}, s.conversionError(err, v, typ)
+	}
+	return s.convertNumber(reflect.ValueOf(f), typ)
+}
+
+func (s *state) formatJSONNumber(v reflect.Value, typ reflect.Type) (reflect.Value, error) {
+	var str string
+	switch {
+	case isIntKind(v.Kind()):
+		str = strconv.FormatInt(v.Int(), 10)
+	case isUintKind(v.Kind()):
+		str = strconv.FormatUint(v.Uint(), 10)
+	default:
+		f := v.Float()
+		if math.IsInf(f, 0) || math.IsNaN(f) {
+			return reflect.Value{}, newValueError(ErrNotAssignable, *s, typ, v.Type())
+		}
+		str = strconv.FormatFloat(f, 'f', -1, v.Type().Bits())
+	}
+	return reflect.ValueOf(json.Number(str)).Convert(typ), nil
+}
+
+// convertNumber converts the number v to the numeric type typ, returning
+// ErrOverflow if v is out of the range of typ or ErrNotAssignable if v can not
+// otherwise be represented by typ. A float converted to float32 is rounded;
+// an integer must be exactly representable.
+func (s *state) convertNumber(v reflect.Value, typ reflect.Type) (reflect.Value, error) {
+	out := reflect.New(typ).Elem()
+	overflow := func() (reflect.Value, error) {
+		return reflect.Value{}, newValueError(ErrOverflow, *s, typ, v.Type())
+	}
+	inexact := func() (reflect.Value, error) {
+		return reflect.Value{}, newValueError(ErrNotAssignable, *s, typ, v.Type())
+	}
+	switch {
+	case isIntKind(typ.Kind()):
+		var i int64
+		switch {
+		case isIntKind(v.Kind()):
+			i = v.Int()
+		case isUintKind(v.Kind()):
+			if v.Uint() > math.MaxInt64 {
+				return overflow()
+			}
+			i = int64(v.Uint())
+		default:
+			f := v.Float()
+			if math.IsNaN(f) || f != math.Trunc(f) {
+				return inexact()
+			}
+			if f < math.MinInt64 || f >= math.MaxInt64 {
+				return overflow()
+			}
+			i = int64(f)
+		}
+		if out.OverflowInt(i) {
+			return overflow()
+		}
+		out.SetInt(i)
+	case isUintKind(typ.Kind()):
+		var u uint64
+		switch {
+		case isIntKind(v.Kind()):
+			if v.Int() < 0 {
+				return overflow()
+			}
+			u = uint64(v.Int())
+		case isUintKind(v.Kind()):
+			u = v.Uint()
+		default:
+			f := v.Float()
+			if math.IsNaN(f) || f != math.Trunc(f) {
+				return inexact()
+			}
+			if f < 0 || f >= math.MaxUint64 {
+				return overflow()
+			}
+			u = uint64(f)
+		}
+		if out.OverflowUint(u) {
+			return overflow()
+		}
+		out.SetUint(u)
+	default:
+		var f float64
+		switch {
+		case isIntKind(v.Kind()):
+			f = float64(v.Int())
+			if f >= math.MaxInt64 || int64(f) != v.Int() {
+				return inexact()
+			}
+		case isUintKind(v.Kind()):
+			f = float64(v.Uint())
+			if f >= math.MaxUint64 || uint64(f) != v.Uint() {
+				return inexact()
+			}
+		default:
+			f = v.Float()
+		}
+		if out.OverflowFloat(f) {
+			return overflow()
+		}
+		// fractions are rounded to the nearest float32, as encoding/json
+		// would, but integers must be retained
+		if typ.Kind() == reflect.Float32 && !isFloatKind(v.Kind()) && float64(float32(f)) != f {
+			return inexact()
+		}
+		out.SetFloat(f)
+	}
+	return out, nil
+}
+
+// decodeInto converts v to typ by encoding v and decoding the result into a
+// new value of typ. Raw JSON scanned from the source is instead decoded into
+// typ directly; see decodeRaw.
+func (s *state) decodeInto(v reflect.Value, typ reflect.Type) (reflect.Value, error) {
+	c := s.opts.codec()
+	b, err := c.Marshal(v.Interface())
+	if err != nil {
+		return reflect.Value{}, s.conversionError(err, v, typ)
+	}
+	nv := reflect.New(typ)
+	if err = c.Unmarshal(b, nv.Interface()); err != nil {
+		return reflect.Value{}, s.conversionError(err, v, typ)
+	}
+	return nv.Elem(), nil
+}
+
+func (s *state) conversionError(err error, v reflect.Value, typ reflect.Type) error {
+	return newValueError(fmt.Errorf("%w: %v", ErrNotAssignable, err), *s, typ, v.Type())
+}
+
+func isNumberKind(k reflect.Kind) bool {
+	return isIntKind(k) || isUintKind(k) || k == reflect.Float32 || k == reflect.Float64
+}
+
+func isIntKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return true
+	default:
+		return false
+	}
+}
+
+func isUintKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return true
+	default:
+		return false
+	}
+}
+
+func isFloatKind(k reflect.Kind) bool {
+	return k == reflect.Float32 || k == reflect.Float64
+}
@@ -0,0 +1,228 @@
+// Copyright 2022 Chance Dinkins
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+//
+// The License can be found in the LICENSE file.
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonpointer_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/chanced/jsonpointer"
+	"github.com/stretchr/testify/require"
+)
+
+type level string
+
+func TestResolveConversion(t *testing.T) {
+	assert := require.New(t)
+	doc := []byte(`{
+		"int": 42,
+		"neg": -1,
+		"frac": 1.5,
+		"tenth": 0.1,
+		"wide": 16777217,
+		"big": 1e300,
+		"exp": 1e3,
+		"str": "foo",
+		"bool": true,
+		"time": "2022-03-04T05:06:07Z",
+		"duration": "1m30s",
+		"ip": "127.0.0.1",
+		"bad_time": "yesterday",
+		"nested": {"port": 8080}
+	}`)
+
+	tests := []struct {
+		ptr      jsonpointer.Pointer
+		expected interface{}
+		err      error
+	}{
+		{"/int", int(42), nil},
+		{"/int", int8(42), nil},
+		{"/int", uint16(42), nil},
+		{"/int", float32(42), nil},
+		{"/int", json.Number("42"), nil},
+		{"/exp", int64(1000), nil},
+		{"/frac", float32(1.5), nil},
+		{"/frac", json.Number("1.5"), nil},
+		{"/frac", int(0), jsonpointer.ErrNotAssignable},
+		{"/neg", int(-1), nil},
+		{"/neg", uint(0), jsonpointer.ErrOverflow},
+		{"/big", int64(0), jsonpointer.ErrOverflow},
+		{"/big", float32(0), jsonpointer.ErrOverflow},
+		{"/tenth", float64(0.1), nil},
+		{"/tenth", float32(0.1), nil},
+		{"/wide", float64(16777217), nil},
+		{"/nested/port", int8(0), jsonpointer.ErrOverflow},
+		{"/nested/port", uint16(8080), nil},
+		{"/str", "foo", nil},
+		{"/str", level("foo"), nil},
+		{"/str", int(0), jsonpointer.ErrNotAssignable},
+		{"/bool", true, nil},
+		{"/time", time.Date(2022, 3, 4, 5, 6, 7, 0, time.UTC), nil},
+		{"/bad_time", time.Time{}, jsonpointer.ErrNotAssignable},
+		{"/duration", 90 * time.Second, nil},
+		{"/str", time.Duration(0), jsonpointer.ErrNotAssignable},
+		{"/ip", net.ParseIP("127.0.0.1"), nil},
+	}
+
+	for i, test := range tests {
+		fmt.Printf("=== RUN TestResolveConversion #%d, pointer %s into %T\n", i, test.ptr, test.expected)
+		dst := reflect.New(reflect.TypeOf(test.expected))
+		err := jsonpointer.Resolve(doc, test.ptr, dst.Interface())
+		if test.err != nil {
+			assert.ErrorIs(err, test.err, "test %d", i)
+			_, ok := jsonpointer.AsValueError(err)
+			assert.True(ok, "test %d", i)
+			assert.True(dst.Elem().IsZero(), "test %d", i)
+		} else {
+			assert.NoError(err, "test %d", i)
+			assert.Equal(test.expected, dst.Elem().Interface(), "test %d", i)
+		}
+		fmt.Println("--- PASS")
+	}
+}
+
+func TestResolveConversionFromValues(t *testing.T) {
+	assert := require.New(t)
+	src := map[string]interface{}{
+		"number":     json.Number("12345678901234567890"),
+		"int":        json.Number("-7"),
+		"max":        uint64(math.MaxUint64),
+		"precise":    int64(1<<53 + 1),
+		"precisenum": json.Number("9007199254740993"),
+		"wide":       int32(1<<24 + 1),
+		"exact":      int32(1 << 24),
+		"tenth":      0.1,
+		"ptr":        &Entry{Name: "entry"},
+	}
+
+	var u uint64
+	err := jsonpointer.Resolve(src, "/number", &u)
+	assert.NoError(err)
+	assert.Equal(uint64(12345678901234567890), u)
+
+	var i int64
+	err = jsonpointer.Resolve(src, "/number", &i)
+	assert.ErrorIs(err, jsonpointer.ErrOverflow)
+
+	var i8 int8
+	err = jsonpointer.Resolve(src, "/int", &i8)
+	assert.NoError(err)
+	assert.Equal(int8(-7), i8)
+
+	err = jsonpointer.Resolve(src, "/max", &i)
+	assert.ErrorIs(err, jsonpointer.ErrOverflow)
+
+	var f float64
+	err = jsonpointer.Resolve(src, "/precise", &f)
+	assert.ErrorIs(err, jsonpointer.ErrNotAssignable)
+
+	var f32 float32
+	err = jsonpointer.Resolve(src, "/wide", &f32)
+	assert.ErrorIs(err, jsonpointer.ErrNotAssignable)
+	err = jsonpointer.Resolve(src, "/exact", &f32)
+	assert.NoError(err)
+	assert.Equal(float32(1<<24), f32)
+
+	// fractions are rounded
+	err = jsonpointer.Resolve(src, "/tenth", &f32)
+	assert.NoError(err)
+	assert.Equal(float32(0.1), f32)
+
+	err = jsonpointer.Resolve(src, "/precisenum", &f)
+	assert.ErrorIs(err, jsonpointer.ErrNotAssignable)
+
+	var ip *int
+	err = jsonpointer.Resolve(src, "/int", &ip)
+	assert.NoError(err)
+	assert.Equal(-7, *ip)
+
+	var e Entry
+	err = jsonpointer.Resolve(src, "/ptr", &e)
+	assert.NoError(err)
+	assert.Equal("entry", e.Name)
+}
+
+type template struct {
+	Name   string            `json:"name"`
+	Labels map[string]string `json:"labels"`
+	Ports  []int             `json:"ports"`
+}
+
+func TestResolveDecodeInto(t *testing.T) {
+	assert := require.New(t)
+	doc := []byte(`{
+		"spec": {
+			"template": {
+				"name": "web",
+				"labels": {"app": "web"},
+				"ports": [80, 443]
+			},
+			"invalid": {"ports": "eighty"}
+		}
+	}`)
+
+	var tmpl template
+	err := jsonpointer.Resolve(doc, "/spec/template", &tmpl)
+	assert.NoError(err)
+	assert.Equal(template{
+		Name:   "web",
+		Labels: map[string]string{"app": "web"},
+		Ports:  []int{80, 443},
+	}, tmpl)
+
+	var tp *template
+	err = jsonpointer.Resolve(doc, "/spec/template", &tp)
+	assert.NoError(err)
+	assert.Equal("web", tp.Name)
+
+	var labels map[string]string
+	err = jsonpointer.Resolve(doc, "/spec/template/labels", &labels)
+	assert.NoError(err)
+	assert.Equal(map[string]string{"app": "web"}, labels)
+
+	var ports []uint16
+	err = jsonpointer.Resolve(doc, "/spec/template/ports", &ports)
+	assert.NoError(err)
+	assert.Equal([]uint16{80, 443}, ports)
+
+	var generic map[string]interface{}
+	err = jsonpointer.Resolve(doc, "/spec/template/labels", &generic)
+	assert.NoError(err)
+	assert.Equal(map[string]interface{}{"app": "web"}, generic)
+
+	var invalid template
+	err = jsonpointer.Resolve(doc, "/spec/invalid", &invalid)
+	assert.ErrorIs(err, jsonpointer.ErrNotAssignable)
+
+	// raw JSON is decoded directly so that numbers beyond the precision of a
+	// float64 are retained
+	var id struct {
+		ID int64 `json:"id"`
+	}
+	err = jsonpointer.Resolve([]byte(`{"tmpl": {"id": 9007199254740993}}`), "/tmpl", &id)
+	assert.NoError(err)
+	assert.Equal(int64(9007199254740993), id.ID)
+
+	// values which are not generic JSON are not decoded
+	src := map[string]interface{}{"entry": map[string]string{"name": "x"}}
+	var e Entry
+	err = jsonpointer.Resolve(src, "/entry", &e)
+	assert.ErrorIs(err, jsonpointer.ErrNotAssignable)
+}
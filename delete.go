@@ -13,7 +13,10 @@
 
 package jsonpointer
 
-import "reflect"
+import (
+	"context"
+	"reflect"
+)
 
 // Deleter is an interface that is implemented by any type which can delete a
 // value by JSON pointer.
@@ -21,14 +24,32 @@ type Deleter interface {
 	DeleteByJSONPointer(ptr *Pointer) error
 }
 
+// ContextDeleter is a Deleter which is provided the context.Context of the
+// operation. If a type implements both ContextDeleter and Deleter,
+// DeleteByJSONPointerContext is preferred.
+type ContextDeleter interface {
+	DeleteByJSONPointerContext(ctx context.Context, ptr *Pointer) error
+}
+
 // Delete deletes the value at the given JSON pointer from src.
 //
 // If any part of the path is unreachable, the Delete function is
 // considered a success as the value is not present to delete.
-func Delete(src interface{}, ptr Pointer) error {
+//
+// The behavior of Delete can be configured with opts. See Options.
+func Delete(src interface{}, ptr Pointer, opts ...Option) error {
+	return DeleteContext(context.Background(), src, ptr, opts...)
+}
+
+// DeleteContext is Delete with a context.Context which is passed to any
+// ContextResolver or ContextDeleter in the path. ctx is checked for
+// cancellation between tokens; if it is done, the returned error wraps
+// ctx.Err().
+func DeleteContext(ctx context.Context, src interface{}, ptr Pointer, opts ...Option) error {
 	dv := reflect.ValueOf(src)
-	s := newState(ptr, Deleting)
+	s := newState(ptr, Deleting, newOptions(opts))
 	defer s.Release()
+	s.ctx = ctx
 	if err := ptr.Validate(); err != nil {
 		return newError(err, *s, dv.Type())
 	}
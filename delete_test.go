@@ -44,6 +44,25 @@ func TestDelete(t *testing.T) {
 			assert.NotContains(r.Nested.Deleter.Values, "key")
 			assert.NoError(err)
 		}},
+		{"/nested/nested", Root{Nested: Nested{Nested: &Nested{Str: "str val"}, Int: 5}}, func(r Root, err error) {
+			assert.NoError(err)
+			assert.Nil(r.Nested.Nested)
+			assert.Equal(5, r.Nested.Int)
+		}},
+		{"/nested/entrymap/foo", Root{Nested: Nested{EntryMap: map[string]*Entry{"foo": {Name: "foo"}, "bar": {Name: "bar"}}}}, func(r Root, err error) {
+			assert.NoError(err)
+			assert.NotContains(r.Nested.EntryMap, "foo")
+			assert.Contains(r.Nested.EntryMap, "bar")
+		}},
+		{"/nested/strslice/1", Root{Nested: Nested{StrSlice: []string{"0", "1", "2"}}}, func(r Root, err error) {
+			assert.NoError(err)
+			assert.Equal([]string{"0", "2"}, r.Nested.StrSlice)
+		}},
+		{"/nested/entryslice/0", Root{Nested: Nested{EntrySlice: []*Entry{{Name: "foo"}, {Name: "bar"}}}}, func(r Root, err error) {
+			assert.NoError(err)
+			assert.Len(r.Nested.EntrySlice, 1)
+			assert.Equal("bar", r.Nested.EntrySlice[0].Name)
+		}},
 	}
 
 	for i, test := range tests {
@@ -52,6 +71,29 @@ func TestDelete(t *testing.T) {
 		test.run(test.root, err)
 
 	}
+
+	// a slice is removed as a whole
+	m := map[string]interface{}{"tags": []interface{}{1, 2}, "name": "svc"}
+	err := jsonpointer.Delete(&m, "/tags")
+	assert.NoError(err)
+	assert.Equal(map[string]interface{}{"name": "svc"}, m)
+
+	r := Root{Nested: Nested{StrSlice: []string{"0"}, Int: 5}}
+	err = jsonpointer.Delete(&r, "/nested/strslice")
+	assert.NoError(err)
+	assert.Nil(r.Nested.StrSlice)
+	assert.Equal(5, r.Nested.Int)
+
+	// deleting a missing member does not remove its parent
+	m = map[string]interface{}{"plugins": map[string]interface{}{}}
+	err = jsonpointer.Delete(&m, "/plugins/missing")
+	assert.NoError(err)
+	assert.Contains(m, "plugins")
+
+	doc := []byte(`{"a":1}`)
+	err = jsonpointer.Delete(&doc, "/missing/key")
+	assert.NoError(err)
+	assert.Equal(`{"a":1}`, string(doc))
 }
 
 func TestDeleteJSON(t *testing.T) {
@@ -94,3 +136,16 @@ func TestDeleteJSON(t *testing.T) {
 		test.run(r, err)
 	}
 }
+
+func TestDeleteJSONPreservesNumbers(t *testing.T) {
+	assert := require.New(t)
+	doc := []byte(`{"id":9007199254740993,"ratio":0.10,"tmp":true,"list":[1e2,-0.0,12345678901234567890]}`)
+
+	err := jsonpointer.Delete(&doc, "/tmp")
+	assert.NoError(err)
+	assert.Equal(`{"id":9007199254740993,"list":[1e2,-0.0,12345678901234567890],"ratio":0.10}`, string(doc))
+
+	err = jsonpointer.Delete(&doc, "/list/0")
+	assert.NoError(err)
+	assert.Equal(`{"id":9007199254740993,"list":[-0.0,12345678901234567890],"ratio":0.10}`, string(doc))
+}
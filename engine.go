@@ -0,0 +1,91 @@
+// Copyright 2022 Chance Dinkins
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+//
+// The License can be found in the LICENSE file.
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonpointer
+
+import "context"
+
+// Engine performs operations with a reusable set of Options. An Engine is
+// safe for concurrent use.
+//
+// Option values passed to the methods of an Engine are applied on top of the
+// Engine's Options for that call only.
+type Engine struct {
+	opts Options
+}
+
+// NewEngine returns an Engine configured with the recommended defaults (see
+// NewOptions), modified by opts.
+func NewEngine(opts ...Option) *Engine {
+	o := NewOptions(opts...)
+	// the keys may be shared with Options passed to WithOptions
+	o.TagKeys = append([]string(nil), o.TagKeys...)
+	return &Engine{opts: o}
+}
+
+// Options returns a copy of the Options of e.
+func (e *Engine) Options() Options {
+	o := e.opts
+	o.TagKeys = append([]string(nil), o.TagKeys...)
+	return o
+}
+
+// Resolve is Resolve with the Options of e.
+func (e *Engine) Resolve(src interface{}, ptr Pointer, dst interface{}, opts ...Option) error {
+	return ResolveContext(context.Background(), src, ptr, dst, e.with(opts)...)
+}
+
+// ResolveContext is ResolveContext with the Options of e.
+func (e *Engine) ResolveContext(ctx context.Context, src interface{}, ptr Pointer, dst interface{}, opts ...Option) error {
+	return ResolveContext(ctx, src, ptr, dst, e.with(opts)...)
+}
+
+// Assign is Assign with the Options of e.
+func (e *Engine) Assign(dst interface{}, ptr Pointer, value interface{}, opts ...Option) error {
+	return AssignContext(context.Background(), dst, ptr, value, e.with(opts)...)
+}
+
+// AssignContext is AssignContext with the Options of e.
+func (e *Engine) AssignContext(ctx context.Context, dst interface{}, ptr Pointer, value interface{}, opts ...Option) error {
+	return AssignContext(ctx, dst, ptr, value, e.with(opts)...)
+}
+
+// Delete is Delete with the Options of e.
+func (e *Engine) Delete(src interface{}, ptr Pointer, opts ...Option) error {
+	return DeleteContext(context.Background(), src, ptr, e.with(opts)...)
+}
+
+// DeleteContext is DeleteContext with the Options of e.
+func (e *Engine) DeleteContext(ctx context.Context, src interface{}, ptr Pointer, opts ...Option) error {
+	return DeleteContext(ctx, src, ptr, e.with(opts)...)
+}
+
+// ResolveAll is ResolveAll with the Options of e.
+func (e *Engine) ResolveAll(src interface{}, dsts map[Pointer]interface{}, opts ...Option) error {
+	return ResolveAll(src, dsts, e.with(opts)...)
+}
+
+// Lookup is Lookup with the Options of e.
+func (e *Engine) Lookup(src interface{}, ptr Pointer, opts ...Option) (interface{}, Presence, error) {
+	return Lookup(src, ptr, e.with(opts)...)
+}
+
+// Has is Has with the Options of e.
+func (e *Engine) Has(src interface{}, ptr Pointer, opts ...Option) bool {
+	return Has(src, ptr, e.with(opts)...)
+}
+
+// with returns opts preceded by an Option which applies the Options of e.
+func (e *Engine) with(opts []Option) []Option {
+	return append([]Option{WithOptions(e.opts)}, opts...)
+}
@@ -0,0 +1,76 @@
+// Copyright 2022 Chance Dinkins
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+//
+// The License can be found in the LICENSE file.
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonpointer_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/chanced/jsonpointer"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEngine(t *testing.T) {
+	assert := require.New(t)
+	e := jsonpointer.NewEngine(jsonpointer.WithCaseSensitive(true))
+	assert.Equal(jsonpointer.NewOptions(jsonpointer.WithCaseSensitive(true)), e.Options())
+
+	// the Options of an Engine can not be modified through those returned
+	o := jsonpointer.NewOptions(jsonpointer.WithTagKeys("yaml", "json"))
+	te := jsonpointer.NewEngine(jsonpointer.WithOptions(o))
+	o.TagKeys[0] = "modified"
+	te.Options().TagKeys[1] = "modified"
+	assert.Equal([]string{"yaml", "json"}, te.Options().TagKeys)
+
+	r := Root{Nested: Nested{Str: "strval", StrSlice: []string{"foo", "bar"}}}
+
+	var s string
+	err := e.Resolve(r, "/nested/str", &s)
+	assert.NoError(err)
+	assert.Equal("strval", s)
+
+	err = e.Resolve(r, "/Nested/str", &s)
+	assert.ErrorIs(err, jsonpointer.ErrNotFound)
+
+	// options passed to a call apply only to that call
+	err = e.Resolve(r, "/Nested/str", &s, jsonpointer.WithCaseSensitive(false))
+	assert.NoError(err)
+	err = e.Resolve(r, "/Nested/str", &s)
+	assert.ErrorIs(err, jsonpointer.ErrNotFound)
+
+	// recommended defaults apply
+	err = e.Resolve(r, "/nested/strslice/01", &s)
+	assert.ErrorIs(err, jsonpointer.ErrNonCanonicalIndex)
+
+	err = e.Assign(&r, "/nested/str", "x")
+	assert.NoError(err)
+	assert.Equal("x", r.Nested.Str)
+
+	err = e.Delete(&r, "/nested/strslice/0")
+	assert.NoError(err)
+	assert.Equal([]string{"bar"}, r.Nested.StrSlice)
+
+	assert.True(e.Has(r, "/nested/str"))
+	assert.False(e.Has(r, "/NESTED/str"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = e.ResolveContext(ctx, r, "/nested/str", &s)
+	assert.ErrorIs(err, context.Canceled)
+	err = e.AssignContext(ctx, &r, "/nested/str", "y")
+	assert.ErrorIs(err, context.Canceled)
+	err = e.DeleteContext(ctx, &r, "/nested/str")
+	assert.ErrorIs(err, context.Canceled)
+	assert.Equal("x", r.Nested.Str)
+}
@@ -35,6 +35,21 @@ var (
 
 	ErrMalformedEncoding = fmt.Errorf("%w; '~' must be encoded as ~0", ErrMalformedToken)
 
+	// ErrMalformedFragment is an ErrMalformedToken that is returned when a URI
+	// fragment contains an invalid percent-encoding or does not decode to
+	// valid UTF-8.
+	ErrMalformedFragment = fmt.Errorf("%w; URI fragment is not properly percent-encoded", ErrMalformedToken)
+
+	// ErrMalformedRelativePointer is an ErrMalformedToken that is returned
+	// when a RelativePointer does not start with a non-negative integer,
+	// optionally followed by an index manipulation, and then either "#" or a
+	// JSON Pointer.
+	ErrMalformedRelativePointer = fmt.Errorf("%w; relative pointer must start with a non-negative integer", ErrMalformedToken)
+
+	// ErrUnsupportedSegment is returned from NewFrom when a segment is of a type
+	// which can not be encoded as a token.
+	ErrUnsupportedSegment = errors.New("jsonpointer: unsupported segment type")
+
 	// ErrNonPointer indicates a non-pointer value was passed to Assign.
 	//
 	ErrNonPointer = errors.New("jsonpointer: dst must be a pointer")
@@ -44,6 +59,11 @@ var (
 	//
 	ErrUnexportedField = errors.New("jsonpointer: unexported field")
 
+	// ErrReadOnly is returned as a FieldError when attempting to assign to or
+	// delete a struct field, or a value within it, which is tagged readonly
+	// (e.g. `jsonpointer:",readonly"`).
+	ErrReadOnly = errors.New("jsonpointer: field is read-only")
+
 	// ErrInvalidKeyType indicates the key type is not supported.
 	//
 	// Custom key types must implement encoding.TextUnmarshaler
@@ -55,6 +75,10 @@ var (
 	//
 	ErrNotAssignable = errors.New("jsonpointer: invalid value type")
 
+	// ErrOverflow is an ErrNotAssignable that is returned when a number is out
+	// of the range of the numeric type it is being converted to.
+	ErrOverflow = fmt.Errorf("%w; value overflows destination type", ErrNotAssignable)
+
 	// ErrNotFound indicates a JSONPointer is not reachable from the root object
 	// (e.g. a nil pointer, missing map key).
 	//
@@ -70,6 +94,13 @@ var (
 	//
 	ErrUnreachable = fmt.Errorf("%w due to being unreachable", ErrNotFound)
 
+	// ErrRelativeOrigin indicates a RelativePointer can not be evaluated
+	// against the base Pointer. This occurs when moving up more levels than
+	// the base has, when referencing the key of the root, or when applying an
+	// index manipulation to a value which is not an element of an array.
+	//
+	ErrRelativeOrigin = fmt.Errorf("%w; relative pointer origin is not reachable from base", ErrNotFound)
+
 	// ErrNilInterface is returned when assigning and a nil interface is
 	// reached.
 	//
@@ -80,6 +111,11 @@ var (
 
 	// ErrMalformedIndex indicates a syntax error in the index or a slice or an array.
 	ErrMalformedIndex = errors.New("jsonpointer: malformed slice or array index")
+
+	// ErrNonCanonicalIndex is an ErrMalformedIndex that is returned when
+	// Options.StrictIndex is enabled and an index does not conform to the
+	// array-index grammar of RFC 6901 (e.g. "+1", "01", "-0").
+	ErrNonCanonicalIndex = fmt.Errorf("%w; index must be 0 or start with a non-zero digit", ErrMalformedIndex)
 )
 
 // Error is a base error type returned from Resolve, Assign, and Delete.
@@ -93,6 +129,22 @@ type Error interface {
 	Type() reflect.Type
 }
 
+// isNotFound returns true if err indicates that a value is not present, either
+// because a key, field, or index does not exist or because the path is
+// unreachable.
+func isNotFound(err error) bool {
+	switch {
+	case errors.Is(err, ErrNotFound),
+		errors.Is(err, ErrOutOfRange),
+		errors.Is(err, ErrMalformedIndex),
+		errors.Is(err, ErrUnexportedField),
+		IsKeyError(err):
+		return true
+	}
+	var ie IndexError
+	return errors.As(err, &ie)
+}
+
 func isError(err error) bool {
 	_, ok := err.(Error)
 	return ok
@@ -212,12 +264,35 @@ func (e *keyError) KeyValue() interface{} {
 // FieldError indicates an error occurred with regards to a field of a struct.
 type FieldError interface {
 	Error
+	// Field returns the struct field pertinent to the error. If the error is
+	// ErrNotFound, the field is the one suggested, if any.
 	Field() reflect.StructField
+	// Suggestion returns the token of a field which matches the token of the
+	// error case-insensitively. It is only available if the error is
+	// ErrNotFound and Options.CaseSensitive is enabled.
+	Suggestion() (Token, bool)
+}
+
+// AsFieldError returns err as a FieldError, if possible. It does so by calling
+// errors.As, returning a FieldError and true if successful. If unsuccessful,
+// nil and false is returned.
+func AsFieldError(err error) (FieldError, bool) {
+	var e FieldError
+	return e, errors.As(err, &e)
 }
 
 type fieldError struct {
 	ptrError
-	field reflect.StructField
+	field      reflect.StructField
+	suggestion Token
+}
+
+func (e *fieldError) Field() reflect.StructField {
+	return e.field
+}
+
+func (e *fieldError) Suggestion() (Token, bool) {
+	return e.suggestion, e.suggestion != ""
 }
 
 func (e *fieldError) Error() string {
@@ -228,6 +303,8 @@ func (e *fieldError) Error() string {
 		} else {
 			return "jsonpointer: unexported field: " + e.typ.String() + "." + e.field.Name
 		}
+	case e.suggestion != "":
+		return fmt.Sprintf(`%v; did you mean "%s"?`, e.ptrError.Error(), e.suggestion)
 	default:
 		return e.ptrError.Error()
 	}
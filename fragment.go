@@ -0,0 +1,148 @@
+// Copyright 2022 Chance Dinkins
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+//
+// The License can be found in the LICENSE file.
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonpointer
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+const upperhex = "0123456789ABCDEF"
+
+// ParseURIFragment parses fragment as the URI fragment identifier
+// representation of a JSON Pointer, as defined in section 6 of RFC 6901.
+//
+// A leading '#' is trimmed if present and the remainder is percent-decoded
+// before being validated as a Pointer.
+//
+// Examples:
+//
+//	jsonpointer.ParseURIFragment("#/definitions/a%20b") => "/definitions/a b"
+//	jsonpointer.ParseURIFragment("#/%E2%82%AC") => "/€"
+//	jsonpointer.ParseURIFragment("#") => ""
+func ParseURIFragment(fragment string) (Pointer, error) {
+	if len(fragment) > 0 && fragment[0] == '#' {
+		fragment = fragment[1:]
+	}
+	s, err := unescapeFragment(fragment)
+	if err != nil {
+		return Root, err
+	}
+	p := Pointer(s)
+	return p, p.Validate()
+}
+
+// URIFragment returns the URI fragment identifier representation of p,
+// including the leading '#', as defined in section 6 of RFC 6901.
+//
+// Characters which are not permitted in a URI fragment are percent-encoded
+// as UTF-8. The result can be parsed back into p with ParseURIFragment.
+//
+// Examples:
+//
+//	jsonpointer.Pointer("/definitions/a b").URIFragment() => "#/definitions/a%20b"
+//	jsonpointer.Pointer("/€").URIFragment() => "#/%E2%82%AC"
+//	jsonpointer.Root.URIFragment() => "#"
+func (p Pointer) URIFragment() string {
+	n := 0
+	for i := 0; i < len(p); i++ {
+		if !isFragmentChar(p[i]) {
+			n++
+		}
+	}
+	b := &strings.Builder{}
+	b.Grow(len(p) + 2*n + 1)
+	b.WriteByte('#')
+	for i := 0; i < len(p); i++ {
+		c := p[i]
+		if isFragmentChar(c) {
+			b.WriteByte(c)
+			continue
+		}
+		b.WriteByte('%')
+		b.WriteByte(upperhex[c>>4])
+		b.WriteByte(upperhex[c&15])
+	}
+	return b.String()
+}
+
+// isFragmentChar reports whether c may appear unencoded within a URI
+// fragment, per the fragment production of RFC 3986:
+//
+//	fragment    = *( pchar / "/" / "?" )
+//	pchar       = unreserved / pct-encoded / sub-delims / ":" / "@"
+//	unreserved  = ALPHA / DIGIT / "-" / "." / "_" / "~"
+//	sub-delims  = "!" / "$" / "&" / "'" / "(" / ")" / "*" / "+" / "," / ";" / "="
+func isFragmentChar(c byte) bool {
+	switch {
+	case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9':
+		return true
+	}
+	switch c {
+	case '-', '.', '_', '~',
+		'!', '$', '&', '\'', '(', ')', '*', '+', ',', ';', '=',
+		':', '@', '/', '?':
+		return true
+	}
+	return false
+}
+
+// unescapeFragment percent-decodes s. Unlike url.QueryUnescape, '+' is left
+// as is. The decoded value must be valid UTF-8.
+func unescapeFragment(s string) (string, error) {
+	i := strings.IndexByte(s, '%')
+	if i == -1 {
+		if !utf8.ValidString(s) {
+			return "", ErrMalformedFragment
+		}
+		return s, nil
+	}
+	b := make([]byte, 0, len(s))
+	b = append(b, s[:i]...)
+	for ; i < len(s); i++ {
+		if s[i] != '%' {
+			b = append(b, s[i])
+			continue
+		}
+		if i+2 >= len(s) {
+			return "", ErrMalformedFragment
+		}
+		hi, ok := unhex(s[i+1])
+		if !ok {
+			return "", ErrMalformedFragment
+		}
+		lo, ok := unhex(s[i+2])
+		if !ok {
+			return "", ErrMalformedFragment
+		}
+		b = append(b, hi<<4|lo)
+		i += 2
+	}
+	if !utf8.Valid(b) {
+		return "", ErrMalformedFragment
+	}
+	return string(b), nil
+}
+
+func unhex(c byte) (byte, bool) {
+	switch {
+	case '0' <= c && c <= '9':
+		return c - '0', true
+	case 'a' <= c && c <= 'f':
+		return c - 'a' + 10, true
+	case 'A' <= c && c <= 'F':
+		return c - 'A' + 10, true
+	}
+	return 0, false
+}
@@ -0,0 +1,84 @@
+// Copyright 2022 Chance Dinkins
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+//
+// The License can be found in the LICENSE file.
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonpointer_test
+
+import (
+	"testing"
+
+	"github.com/chanced/jsonpointer"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseURIFragment(t *testing.T) {
+	assert := require.New(t)
+	tests := []struct {
+		fragment string
+		expected jsonpointer.Pointer
+		err      error
+	}{
+		{"#", "", nil},
+		{"", "", nil},
+		{"#/foo", "/foo", nil},
+		{"#/definitions/a%20b", "/definitions/a b", nil},
+		{"#/%E2%82%AC", "/€", nil},
+		{"#/c%25d", "/c%d", nil},
+		{"#/k%22l", `/k"l`, nil},
+		{"#/a~1b", "/a~1b", nil},
+		{"#/a+b", "/a+b", nil},
+		{"#/%7e", "/~", jsonpointer.ErrMalformedEncoding},
+		{"#/%2", "", jsonpointer.ErrMalformedFragment},
+		{"#/%zz", "", jsonpointer.ErrMalformedFragment},
+		{"#/%FF", "", jsonpointer.ErrMalformedFragment},
+		{"#foo", "foo", jsonpointer.ErrMalformedStart},
+	}
+	for _, test := range tests {
+		p, err := jsonpointer.ParseURIFragment(test.fragment)
+		if test.err != nil {
+			assert.ErrorIs(err, test.err, "fragment %q", test.fragment)
+			assert.ErrorIs(err, jsonpointer.ErrMalformedToken, "fragment %q", test.fragment)
+			continue
+		}
+		assert.NoError(err, "fragment %q", test.fragment)
+		assert.Equal(test.expected, p)
+	}
+}
+
+func TestPointerURIFragment(t *testing.T) {
+	assert := require.New(t)
+	tests := []struct {
+		ptr      jsonpointer.Pointer
+		expected string
+	}{
+		{"", "#"},
+		{"/", "#/"},
+		{"/foo", "#/foo"},
+		{"/foo/0", "#/foo/0"},
+		{"/a~1b", "#/a~1b"},
+		{"/c%d", "#/c%25d"},
+		{"/e^f", "#/e%5Ef"},
+		{"/g|h", "#/g%7Ch"},
+		{"/i\\j", "#/i%5Cj"},
+		{`/k"l`, "#/k%22l"},
+		{"/ ", "#/%20"},
+		{"/m~0n", "#/m~0n"},
+		{"/€", "#/%E2%82%AC"},
+		{"/a#b", "#/a%23b"},
+	}
+	for _, test := range tests {
+		assert.Equal(test.expected, test.ptr.URIFragment())
+		p, err := jsonpointer.ParseURIFragment(test.ptr.URIFragment())
+		assert.NoError(err)
+		assert.Equal(test.ptr, p, "round trip of %q", test.ptr)
+	}
+}
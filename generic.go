@@ -0,0 +1,54 @@
+// Copyright 2022 Chance Dinkins
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+//
+// The License can be found in the LICENSE file.
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonpointer
+
+// Get resolves the value of src at ptr as T. See Resolve.
+//
+//	name, err := jsonpointer.Get[string](src, "/user/name")
+func Get[T any](src interface{}, ptr Pointer, opts ...Option) (T, error) {
+	var v T
+	if err := Resolve(src, ptr, &v, opts...); err != nil {
+		var zero T
+		return zero, err
+	}
+	return v, nil
+}
+
+// GetOr resolves the value of src at ptr as T, returning def if the value can
+// not be resolved for any reason.
+//
+//	limit := jsonpointer.GetOr(src, "/config/limit", 10)
+func GetOr[T any](src interface{}, ptr Pointer, def T, opts ...Option) T {
+	v, err := Get[T](src, ptr, opts...)
+	if err != nil {
+		return def
+	}
+	return v
+}
+
+// MustGet is like Get but panics if the value can not be resolved.
+func MustGet[T any](src interface{}, ptr Pointer, opts ...Option) T {
+	v, err := Get[T](src, ptr, opts...)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Set assigns value to dst at ptr. See Assign.
+//
+//	err := jsonpointer.Set(&doc, "/user/name", "Jane")
+func Set[D any, T any](dst *D, ptr Pointer, value T, opts ...Option) error {
+	return Assign(dst, ptr, value, opts...)
+}
@@ -0,0 +1,92 @@
+// Copyright 2022 Chance Dinkins
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+//
+// The License can be found in the LICENSE file.
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonpointer_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/chanced/jsonpointer"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGet(t *testing.T) {
+	assert := require.New(t)
+	r := Root{Nested: Nested{Str: "strval", IntSlice: []int{1, 2}}}
+
+	s, err := jsonpointer.Get[string](r, "/nested/str")
+	assert.NoError(err)
+	assert.Equal("strval", s)
+
+	i, err := jsonpointer.Get[int](r, "/nested/intslice/1")
+	assert.NoError(err)
+	assert.Equal(2, i)
+
+	i, err = jsonpointer.Get[int](r, "/nested/str")
+	assert.ErrorIs(err, jsonpointer.ErrNotAssignable)
+	assert.Zero(i)
+
+	_, err = jsonpointer.Get[string](r, "/nested/missing")
+	assert.ErrorIs(err, jsonpointer.ErrNotFound)
+
+	doc := []byte(`{"timeout":"5s","retries":3,"tags":["a","b"]}`)
+	d, err := jsonpointer.Get[time.Duration](doc, "/timeout")
+	assert.NoError(err)
+	assert.Equal(5*time.Second, d)
+
+	tags, err := jsonpointer.Get[[]string](doc, "/tags")
+	assert.NoError(err)
+	assert.Equal([]string{"a", "b"}, tags)
+
+	v, err := jsonpointer.Get[interface{}](doc, "/retries")
+	assert.NoError(err)
+	assert.Equal(float64(3), v)
+}
+
+func TestGetOr(t *testing.T) {
+	assert := require.New(t)
+	doc := []byte(`{"retries":3}`)
+	assert.Equal(uint8(3), jsonpointer.GetOr(doc, "/retries", uint8(5)))
+	assert.Equal(uint8(5), jsonpointer.GetOr(doc, "/missing", uint8(5)))
+	assert.Equal("default", jsonpointer.GetOr(doc, "/retries", "default"))
+}
+
+func TestMustGet(t *testing.T) {
+	assert := require.New(t)
+	doc := []byte(`{"retries":3}`)
+	assert.Equal(3, jsonpointer.MustGet[int](doc, "/retries"))
+	assert.Panics(func() {
+		jsonpointer.MustGet[int](doc, "/missing")
+	})
+}
+
+func TestSet(t *testing.T) {
+	assert := require.New(t)
+	var r Root
+	err := jsonpointer.Set(&r, "/nested/str", "strval")
+	assert.NoError(err)
+	assert.Equal("strval", r.Nested.Str)
+
+	err = jsonpointer.Set(&r, "/nested/intslice/-", 7)
+	assert.NoError(err)
+	assert.Equal([]int{7}, r.Nested.IntSlice)
+
+	m := map[string]interface{}{}
+	err = jsonpointer.Set(&m, "/a/b", true)
+	assert.NoError(err)
+	assert.Equal(map[string]interface{}{"a": map[string]interface{}{"b": true}}, m)
+
+	err = jsonpointer.Set(&r, "/nested/str", 1)
+	assert.ErrorIs(err, jsonpointer.ErrNotAssignable)
+}
@@ -0,0 +1,41 @@
+// Copyright 2022 Chance Dinkins
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+//
+// The License can be found in the LICENSE file.
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build go1.23
+
+package jsonpointer
+
+import "iter"
+
+// All returns an iterator over the index and encoded reference token of each
+// of the tokens of p. See Range.
+//
+//	for i, t := range ptr.All() {
+//		...
+//	}
+func (p Pointer) All() iter.Seq2[int, Token] {
+	return p.Range
+}
+
+// Values returns an iterator over the encoded reference tokens of p.
+//
+//	for t := range ptr.Values() {
+//		...
+//	}
+func (p Pointer) Values() iter.Seq[Token] {
+	return func(yield func(Token) bool) {
+		p.Range(func(_ int, t Token) bool {
+			return yield(t)
+		})
+	}
+}
@@ -0,0 +1,44 @@
+// Copyright 2022 Chance Dinkins
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+//
+// The License can be found in the LICENSE file.
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build go1.23
+
+package jsonpointer_test
+
+import (
+	"testing"
+
+	"github.com/chanced/jsonpointer"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPointerAll(t *testing.T) {
+	assert := require.New(t)
+	p := jsonpointer.Pointer("/foo/bar~1baz/0")
+
+	var tokens []jsonpointer.Token
+	for i, tok := range p.All() {
+		assert.Equal(len(tokens), i)
+		tokens = append(tokens, tok)
+	}
+	assert.Equal([]jsonpointer.Token{"foo", "bar~1baz", "0"}, tokens)
+
+	tokens = tokens[:0]
+	for tok := range p.Values() {
+		if tok == "0" {
+			break
+		}
+		tokens = append(tokens, tok)
+	}
+	assert.Equal([]jsonpointer.Token{"foo", "bar~1baz"}, tokens)
+}
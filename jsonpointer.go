@@ -17,6 +17,7 @@ package jsonpointer
 
 import (
 	"errors"
+	"strconv"
 	"strings"
 )
 
@@ -60,6 +61,9 @@ func From(ptr string) (Pointer, error) {
 
 // Parse accepts a string, trims any leading '#' and returns str as a Pointer as
 // well as any validation errors.
+//
+// Parse does not percent-decode ptr. Use ParseURIFragment for pointers in
+// their URI fragment identifier representation (e.g. "#/a%20b").
 func Parse(ptr string) (Pointer, error) {
 	if len(ptr) == 0 {
 		return Root, nil
@@ -117,7 +121,7 @@ func (p Pointer) Append(token Token) Pointer {
 // AppendString encodes and appends token to the value of p and returns the new
 // JSONPointer.
 func (p Pointer) AppendString(token string) Pointer {
-	return p.Append(Token(encoder.Replace(token)))
+	return p.Append(Token(Encode(token)))
 }
 
 // Preppend prepends token to the beginning of the value of p and returns the
@@ -132,7 +136,7 @@ func (p Pointer) Prepend(token Token) Pointer {
 // PrependString encodes and prepends token to the value of p and returns the new
 // JSONPointer.
 func (p Pointer) PrependString(token string) Pointer {
-	return p.Prepend(Token(encoder.Replace(token)))
+	return p.Prepend(Token(Encode(token)))
 }
 
 // Validate performs validation on p. The following checks are performed:
@@ -141,11 +145,38 @@ func (p Pointer) PrependString(token string) Pointer {
 //
 // - p must be properly encoded, meaning that '~' must be immediately followed
 // by a '0' or '1'.
-func (p Pointer) Validate() (err error) {
+//
+// If Options.StrictIndex is enabled by opts, tokens which can be parsed as a
+// non-negative int but are not in the canonical form of an array index (e.g.
+// "01", "+1", "-0") are reported with ErrNonCanonicalIndex. As p is validated
+// without a value, such tokens are reported even if they would address an
+// object member.
+func (p Pointer) Validate(opts ...Option) (err error) {
 	if err = p.validateStart(); err != nil {
 		return err
 	}
-	return p.validateeEncoding()
+	if err = p.validateeEncoding(); err != nil {
+		return err
+	}
+	if newOptions(opts).StrictIndex {
+		return p.validateIndexes()
+	}
+	return nil
+}
+
+func (p Pointer) validateIndexes() error {
+	var err error
+	p.Range(func(_ int, t Token) bool {
+		if t.isNonCanonicalIndex() {
+			err = &indexError{
+				err:   ErrNonCanonicalIndex,
+				index: -1,
+			}
+			return false
+		}
+		return true
+	})
+	return err
 }
 
 func (p Pointer) validateeEncoding() error {
@@ -215,7 +246,137 @@ func (p Pointer) IsRoot() bool {
 	return p == Root
 }
 
-// Tokens returns the decoded tokens of the JSONPointer.
+// Parent returns the Pointer of the parent of p. If p is the Root, the Root
+// and false are returned.
+func (p Pointer) Parent() (Pointer, bool) {
+	parent, _, ok := p.Pop()
+	return parent, ok
+}
+
+// Depth returns the number of reference tokens in p.
+//
+// Examples:
+//
+//	jsonpointer.Pointer("").Depth() => 0
+//	jsonpointer.Pointer("/").Depth() => 1
+//	jsonpointer.Pointer("/foo/bar").Depth() => 2
+func (p Pointer) Depth() int {
+	if !startsWithSlash(p) {
+		return 0
+	}
+	return strings.Count(string(p), "/")
+}
+
+// IsAncestorOf returns true if p is a proper ancestor of q, meaning each of
+// the tokens of p are the leading tokens of q and q has at least one more
+// token.
+//
+// Examples:
+//
+//	jsonpointer.Pointer("/foo").IsAncestorOf("/foo/bar") => true
+//	jsonpointer.Pointer("/foo").IsAncestorOf("/foobar/x") => false
+//	jsonpointer.Pointer("/foo").IsAncestorOf("/foo") => false
+//	jsonpointer.Root.IsAncestorOf("/foo") => true
+func (p Pointer) IsAncestorOf(q Pointer) bool {
+	return len(q) > len(p) && q[:len(p)] == p && q[len(p)] == '/'
+}
+
+// IsDescendantOf returns true if p is a proper descendant of q. See
+// IsAncestorOf.
+func (p Pointer) IsDescendantOf(q Pointer) bool {
+	return q.IsAncestorOf(p)
+}
+
+// CommonAncestor returns the longest Pointer whose tokens lead both p and q.
+// If p is an ancestor of q, p is returned. If p and q are equal, p is
+// returned.
+//
+// Examples:
+//
+//	jsonpointer.Pointer("/foo/bar").CommonAncestor("/foo/baz") => "/foo"
+//	jsonpointer.Pointer("/foo").CommonAncestor("/foobar") => ""
+//	jsonpointer.Pointer("/foo").CommonAncestor("/foo/bar") => "/foo"
+func (p Pointer) CommonAncestor(q Pointer) Pointer {
+	n := len(p)
+	if len(q) < n {
+		n = len(q)
+	}
+	i := 0
+	for i < n && p[i] == q[i] {
+		i++
+	}
+	if i == len(p) && (i == len(q) || q[i] == '/') {
+		return p
+	}
+	if i == len(q) && p[i] == '/' {
+		return q
+	}
+	if i = lastSlash(p[:i]); i == -1 {
+		return Root
+	}
+	return p[:i]
+}
+
+// RelativeTo returns the RelativePointer which, when evaluated against base,
+// references p.
+//
+// Examples:
+//
+//	jsonpointer.Pointer("/foo/0").RelativeTo("/foo/1") => "1/0"
+//	jsonpointer.Pointer("/foo/1/bar").RelativeTo("/foo/1") => "0/bar"
+//	jsonpointer.Pointer("/foo").RelativeTo("/foo") => "0"
+func (p Pointer) RelativeTo(base Pointer) RelativePointer {
+	c := p.CommonAncestor(base)
+	up := base.Depth() - c.Depth()
+	return RelativePointer(strconv.Itoa(up) + string(p[len(c):]))
+}
+
+// NextSibling returns the Pointer of the array element following the one
+// referenced by p. If the last token of p is not an array index, false is
+// returned.
+//
+// Examples:
+//
+//	jsonpointer.Pointer("/foo/1").NextSibling() => "/foo/2", true
+//	jsonpointer.Pointer("/foo/bar").NextSibling() => "", false
+func (p Pointer) NextSibling() (Pointer, bool) {
+	parent, t, ok := p.Pop()
+	if !ok {
+		return Root, false
+	}
+	i, ok := t.canonicalIndex()
+	if !ok {
+		return Root, false
+	}
+	return parent.Append(Token(strconv.Itoa(i + 1))), true
+}
+
+// PrevSibling returns the Pointer of the array element preceding the one
+// referenced by p. If the last token of p is not an array index or is 0, false
+// is returned.
+//
+// Examples:
+//
+//	jsonpointer.Pointer("/foo/1").PrevSibling() => "/foo/0", true
+//	jsonpointer.Pointer("/foo/0").PrevSibling() => "", false
+func (p Pointer) PrevSibling() (Pointer, bool) {
+	parent, t, ok := p.Pop()
+	if !ok {
+		return Root, false
+	}
+	i, ok := t.canonicalIndex()
+	if !ok || i == 0 {
+		return Root, false
+	}
+	return parent.Append(Token(strconv.Itoa(i - 1))), true
+}
+
+// Tokens returns the decoded tokens of the JSONPointer, split on "/". A
+// non-root pointer begins with an empty token for the leading slash; "/foo"
+// yields ["", "foo"].
+//
+// Tokens allocates a new slice on each call. Use Range or TokenAt to
+// iterate over the reference tokens of p without allocating.
 func (p Pointer) Tokens() []string {
 	if p == "" {
 		return []string{}
@@ -227,6 +388,58 @@ func (p Pointer) Tokens() []string {
 	return tokens
 }
 
+// Len returns the number of reference tokens in p. It is equivalent to
+// Depth.
+func (p Pointer) Len() int {
+	return p.Depth()
+}
+
+// Range calls fn for each of the encoded reference tokens of p, in order,
+// along with the index of the token. If fn returns false, iteration stops.
+//
+// Range does not allocate. The Token passed to fn is a substring of p; use
+// t.String() to decode it.
+func (p Pointer) Range(fn func(i int, t Token) bool) {
+	if !startsWithSlash(p) {
+		return
+	}
+	s := string(p[1:])
+	for i := 0; ; i++ {
+		j := strings.IndexByte(s, '/')
+		if j == -1 {
+			fn(i, Token(s))
+			return
+		}
+		if !fn(i, Token(s[:j])) {
+			return
+		}
+		s = s[j+1:]
+	}
+}
+
+// TokenAt returns the encoded reference token of p at index i. If i is out of
+// range, false is returned.
+//
+// Examples:
+//
+//	jsonpointer.Pointer("/foo/bar").TokenAt(1) => "bar", true
+//	jsonpointer.Pointer("/foo/bar").TokenAt(2) => "", false
+func (p Pointer) TokenAt(i int) (Token, bool) {
+	var tok Token
+	var ok bool
+	if i < 0 {
+		return tok, false
+	}
+	p.Range(func(j int, t Token) bool {
+		if j == i {
+			tok, ok = t, true
+			return false
+		}
+		return true
+	})
+	return tok, ok
+}
+
 // func (p *JSONPointer) Resolve(value interface{}, target interface{}) error {
 // }
 
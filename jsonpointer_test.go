@@ -14,6 +14,7 @@
 package jsonpointer_test
 
 import (
+	"errors"
 	"fmt"
 	"testing"
 
@@ -138,3 +139,185 @@ func TestJSONPointerPop(t *testing.T) {
 		assert.Equal(e.expectedok, ok, "expected ok to equal %v, got %v", e.expectedok, ok)
 	}
 }
+
+func TestPointerHierarchy(t *testing.T) {
+	assert := require.New(t)
+	tests := []struct {
+		p            jsonpointer.Pointer
+		q            jsonpointer.Pointer
+		isAncestor   bool
+		isDescendant bool
+		common       jsonpointer.Pointer
+		relative     jsonpointer.RelativePointer
+	}{
+		{"/foo", "/foo/bar", true, false, "/foo", "1"},
+		{"/foo", "/foobar/x", false, false, "", "2/foo"},
+		{"/foo/bar", "/foo", false, true, "/foo", "0/bar"},
+		{"/foo", "/foo", false, false, "/foo", "0"},
+		{"", "/foo", true, false, "", "1"},
+		{"", "", false, false, "", "0"},
+		{"/", "/foo", false, false, "", "1/"},
+		{"/foo/", "/foo/bar", false, false, "/foo", "1/"},
+		{"/a~1b/c", "/a~1b/d", false, false, "/a~1b", "1/c"},
+		{"/a~1b", "/a/b", false, false, "", "2/a~1b"},
+		{"/foo/0", "/foo/1", false, false, "/foo", "1/0"},
+	}
+	for _, test := range tests {
+		assert.Equal(test.isAncestor, test.p.IsAncestorOf(test.q), "%q.IsAncestorOf(%q)", test.p, test.q)
+		assert.Equal(test.isDescendant, test.p.IsDescendantOf(test.q), "%q.IsDescendantOf(%q)", test.p, test.q)
+		assert.Equal(test.common, test.p.CommonAncestor(test.q), "%q.CommonAncestor(%q)", test.p, test.q)
+		assert.Equal(test.common, test.q.CommonAncestor(test.p), "%q.CommonAncestor(%q)", test.q, test.p)
+		assert.Equal(test.relative, test.p.RelativeTo(test.q), "%q.RelativeTo(%q)", test.p, test.q)
+		rp, err := test.relative.Pointer(test.q)
+		assert.NoError(err)
+		assert.Equal(test.p, rp)
+	}
+}
+
+func TestPointerParentDepth(t *testing.T) {
+	assert := require.New(t)
+	tests := []struct {
+		p      jsonpointer.Pointer
+		parent jsonpointer.Pointer
+		ok     bool
+		depth  int
+	}{
+		{"", "", false, 0},
+		{"/", "", true, 1},
+		{"/foo", "", true, 1},
+		{"/foo/bar", "/foo", true, 2},
+		{"/foo~1bar/baz", "/foo~1bar", true, 2},
+		{"/foo//", "/foo/", true, 3},
+	}
+	for _, test := range tests {
+		parent, ok := test.p.Parent()
+		assert.Equal(test.parent, parent)
+		assert.Equal(test.ok, ok)
+		assert.Equal(test.depth, test.p.Depth())
+	}
+}
+
+func TestPointerSiblings(t *testing.T) {
+	assert := require.New(t)
+	tests := []struct {
+		p      jsonpointer.Pointer
+		next   jsonpointer.Pointer
+		nextOk bool
+		prev   jsonpointer.Pointer
+		prevOk bool
+	}{
+		{"/foo/1", "/foo/2", true, "/foo/0", true},
+		{"/foo/0", "/foo/1", true, "", false},
+		{"/9", "/10", true, "/8", true},
+		{"/foo/bar", "", false, "", false},
+		{"/foo/01", "", false, "", false},
+		{"/foo/-", "", false, "", false},
+		{"", "", false, "", false},
+	}
+	for _, test := range tests {
+		next, ok := test.p.NextSibling()
+		assert.Equal(test.next, next)
+		assert.Equal(test.nextOk, ok)
+		prev, ok := test.p.PrevSibling()
+		assert.Equal(test.prev, prev)
+		assert.Equal(test.prevOk, ok)
+	}
+}
+
+func TestPointerRange(t *testing.T) {
+	assert := require.New(t)
+	tests := []struct {
+		p      jsonpointer.Pointer
+		tokens []jsonpointer.Token
+	}{
+		{"", nil},
+		{"/", []jsonpointer.Token{""}},
+		{"/foo", []jsonpointer.Token{"foo"}},
+		{"/foo/bar", []jsonpointer.Token{"foo", "bar"}},
+		{"/foo~1bar/~0", []jsonpointer.Token{"foo~1bar", "~0"}},
+		{"/foo//", []jsonpointer.Token{"foo", "", ""}},
+		{"malformed", nil},
+	}
+	for _, test := range tests {
+		var tokens []jsonpointer.Token
+		test.p.Range(func(i int, tok jsonpointer.Token) bool {
+			assert.Equal(len(tokens), i)
+			tokens = append(tokens, tok)
+			return true
+		})
+		assert.Equal(test.tokens, tokens)
+		assert.Equal(len(test.tokens), test.p.Len())
+		for i, tok := range test.tokens {
+			at, ok := test.p.TokenAt(i)
+			assert.True(ok)
+			assert.Equal(tok, at)
+		}
+		_, ok := test.p.TokenAt(len(test.tokens))
+		assert.False(ok)
+		_, ok = test.p.TokenAt(-1)
+		assert.False(ok)
+	}
+
+	var visited int
+	jsonpointer.Pointer("/foo/bar/baz").Range(func(i int, t jsonpointer.Token) bool {
+		visited++
+		return i < 1
+	})
+	assert.Equal(2, visited)
+}
+
+func TestPointerTokens(t *testing.T) {
+	assert := require.New(t)
+	assert.Equal([]string{}, jsonpointer.Root.Tokens())
+	assert.Equal([]string{"", ""}, jsonpointer.Pointer("/").Tokens())
+	// the leading slash yields an empty first token
+	assert.Equal([]string{"", "foo"}, jsonpointer.Pointer("/foo").Tokens())
+	assert.Equal([]string{"", "", ""}, jsonpointer.Pointer("//").Tokens())
+	assert.Equal([]string{"", "foo", "bar/baz", "~"}, jsonpointer.Pointer("/foo/bar~1baz/~0").Tokens())
+}
+
+func TestPointerRangeAllocs(t *testing.T) {
+	assert := require.New(t)
+	p := jsonpointer.Pointer("/foo/bar/baz/0/qux")
+	var n int
+	allocs := testing.AllocsPerRun(100, func() {
+		p.Range(func(i int, t jsonpointer.Token) bool {
+			n += len(t.String())
+			return true
+		})
+		_, _ = p.TokenAt(3)
+		_ = p.Len()
+	})
+	assert.Zero(allocs)
+}
+
+func TestJSONPointerValidateStrictIndex(t *testing.T) {
+	assert := require.New(t)
+	tests := []struct {
+		ptr jsonpointer.Pointer
+		err error
+	}{
+		{"", nil},
+		{"/foo/0", nil},
+		{"/foo/10", nil},
+		{"/foo/-", nil},
+		{"/foo/-1", nil},
+		{"/foo/bar", nil},
+		{"/foo/01", jsonpointer.ErrNonCanonicalIndex},
+		{"/foo/+1/bar", jsonpointer.ErrNonCanonicalIndex},
+		{"/foo/-0", jsonpointer.ErrNonCanonicalIndex},
+		{"/00", jsonpointer.ErrNonCanonicalIndex},
+		{"foo/01", jsonpointer.ErrMalformedStart},
+	}
+	for _, test := range tests {
+		err := test.ptr.Validate(jsonpointer.WithStrictIndex(true))
+		if test.err != nil {
+			assert.ErrorIs(err, test.err, "pointer %q", test.ptr)
+		} else {
+			assert.NoError(err, "pointer %q", test.ptr)
+		}
+		if !errors.Is(test.err, jsonpointer.ErrMalformedToken) {
+			assert.NoError(test.ptr.Validate(), "pointer %q", test.ptr)
+		}
+	}
+}
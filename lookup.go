@@ -0,0 +1,132 @@
+// Copyright 2022 Chance Dinkins
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+//
+// The License can be found in the LICENSE file.
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonpointer
+
+import (
+	"bytes"
+	"reflect"
+)
+
+const (
+	// Absent indicates there is no value at a Pointer, either because a key,
+	// field, or index does not exist or because a parent is null or not a
+	// container.
+	Absent Presence = iota
+	// Null indicates the value at a Pointer is null: a JSON null, a nil
+	// pointer, interface, map, or slice.
+	Null
+	// Present indicates there is a non-null value at a Pointer, which may be
+	// the zero value of its type.
+	Present
+)
+
+// Presence indicates whether or not a value exists at a Pointer. See Lookup.
+type Presence uint8
+
+func (p Presence) String() string {
+	switch p {
+	case Absent:
+		return "absent"
+	case Null:
+		return "null"
+	case Present:
+		return "present"
+	default:
+		return "unknown"
+	}
+}
+
+// IsAbsent returns true if p is Absent.
+func (p Presence) IsAbsent() bool {
+	return p == Absent
+}
+
+// IsNull returns true if p is Null.
+func (p Presence) IsNull() bool {
+	return p == Null
+}
+
+// IsPresent returns true if p is Present.
+func (p Presence) IsPresent() bool {
+	return p == Present
+}
+
+// Lookup performs resolution on src by traversing the path of the JSON
+// Pointer, returning the value along with its Presence.
+//
+// Unlike Resolve, a path which can not be reached is not considered an error.
+// Missing map keys and object members, out of range indexes, and paths which
+// traverse a null or primitive value result in Absent. A value which is a JSON
+// null or a nil pointer, interface, map, or slice results in Null. All other
+// values, including struct fields set to their zero value, result in Present.
+//
+// An error is returned if ptr is malformed or if resolution otherwise fails,
+// such as when raw JSON is invalid or a Resolver returns an error.
+func Lookup(src interface{}, ptr Pointer, opts ...Option) (interface{}, Presence, error) {
+	v := reflect.ValueOf(src)
+	s := newState(ptr, Resolving, newOptions(opts))
+	defer s.Release()
+	if err := ptr.Validate(); err != nil {
+		return nil, Absent, newError(err, *s, reflect.TypeOf(src))
+	}
+	var t Token
+	var err error
+	for !s.current.IsRoot() {
+		if isNil(v) || isRawNull(v) {
+			return nil, Absent, nil
+		}
+		cur := s.current
+		s.current, t, _ = cur.Next()
+		var nv reflect.Value
+		if nv, err = s.resolveNext(v, t); err != nil {
+			if isNotFound(err) {
+				return nil, Absent, nil
+			}
+			s.current = cur
+			updateErrorState(err, *s)
+			return nil, Absent, err
+		}
+		if !nv.IsValid() {
+			return nil, Absent, nil
+		}
+		v = nv
+	}
+	if isNil(v) || v.Kind() != reflect.Interface && isRawNull(v) {
+		return nil, Null, nil
+	}
+	if !v.CanInterface() {
+		return nil, Present, nil
+	}
+	return v.Interface(), Present, nil
+}
+
+// Has returns true if a value, including null, exists at ptr within src. See
+// Lookup.
+//
+// If ptr is malformed or resolution fails, Has returns false.
+func Has(src interface{}, ptr Pointer, opts ...Option) bool {
+	_, p, err := Lookup(src, ptr, opts...)
+	return err == nil && p != Absent
+}
+
+// isRawNull reports whether v is a byte slice of raw JSON null.
+func isRawNull(v reflect.Value) bool {
+	if !isByteSlice(v) {
+		return false
+	}
+	if v.Kind() == reflect.Interface {
+		v = v.Elem()
+	}
+	return bytes.Equal(bytes.TrimSpace(v.Bytes()), []byte("null"))
+}
@@ -0,0 +1,130 @@
+// Copyright 2022 Chance Dinkins
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+//
+// The License can be found in the LICENSE file.
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonpointer_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/chanced/jsonpointer"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLookup(t *testing.T) {
+	r := Root{
+		Nested: Nested{
+			Str:      "",
+			StrSlice: []string{"foo"},
+			StrMap:   map[string]string{"foo": ""},
+			EntryMap: map[string]*Entry{"nil": nil},
+			JSON:     json.RawMessage(`{"null":null,"zero":0,"obj":{"a":null}}`),
+		},
+	}
+	raw, err := json.Marshal(map[string]interface{}{
+		"null":  nil,
+		"zero":  0,
+		"empty": "",
+		"arr":   []interface{}{nil, 1},
+		"obj":   map[string]interface{}{},
+	})
+	require.NoError(t, err)
+	var tree interface{}
+	require.NoError(t, json.Unmarshal(raw, &tree))
+
+	tests := []struct {
+		src      interface{}
+		ptr      jsonpointer.Pointer
+		presence jsonpointer.Presence
+	}{
+		{r, "", jsonpointer.Present},
+		{r, "/nested/str", jsonpointer.Present},
+		{r, "/nested/int", jsonpointer.Present},
+		{r, "/nested/missing", jsonpointer.Absent},
+		{r, "/nested/nested", jsonpointer.Null},
+		{r, "/nested/nested/str", jsonpointer.Absent},
+		{r, "/nestedptr", jsonpointer.Null},
+		{r, "/nestedptr/str", jsonpointer.Absent},
+		{r, "/nested/str/foo", jsonpointer.Absent},
+		{r, "/nested/strslice/0", jsonpointer.Present},
+		{r, "/nested/strslice/1", jsonpointer.Absent},
+		{r, "/nested/strslice/-", jsonpointer.Absent},
+		{r, "/nested/strslice/foo", jsonpointer.Absent},
+		{r, "/nested/intslice", jsonpointer.Null},
+		{r, "/nested/intslice/0", jsonpointer.Absent},
+		{r, "/nested/strmap/foo", jsonpointer.Present},
+		{r, "/nested/strmap/bar", jsonpointer.Absent},
+		{r, "/nested/uintmap/1", jsonpointer.Absent},
+		{r, "/nested/entrymap/nil", jsonpointer.Null},
+		{r, "/nested/entrymap/nil/name", jsonpointer.Absent},
+		{r, "/nested/json/null", jsonpointer.Null},
+		{r, "/nested/json/zero", jsonpointer.Present},
+		{r, "/nested/json/missing", jsonpointer.Absent},
+		{r, "/nested/json/obj/a", jsonpointer.Null},
+		{r, "/nested/json/obj/a/b", jsonpointer.Absent},
+		{r, "/nested/intarray/2", jsonpointer.Present},
+		{r, "/nested/intarray/3", jsonpointer.Absent},
+		{raw, "/null", jsonpointer.Null},
+		{raw, "/zero", jsonpointer.Present},
+		{raw, "/empty", jsonpointer.Present},
+		{raw, "/missing", jsonpointer.Absent},
+		{raw, "/arr/0", jsonpointer.Null},
+		{raw, "/arr/1", jsonpointer.Present},
+		{raw, "/arr/2", jsonpointer.Absent},
+		{raw, "/obj", jsonpointer.Present},
+		{raw, "/obj/x", jsonpointer.Absent},
+		{raw, "/null/x", jsonpointer.Absent},
+		{json.RawMessage(`null`), "", jsonpointer.Null},
+		{json.RawMessage(`null`), "/a", jsonpointer.Absent},
+		{[]byte(` null `), "/a/b", jsonpointer.Absent},
+		{map[string]interface{}{"raw": json.RawMessage(`null`)}, "/raw/a", jsonpointer.Absent},
+		{tree, "/null", jsonpointer.Null},
+		{tree, "/zero", jsonpointer.Present},
+		{tree, "/missing", jsonpointer.Absent},
+		{tree, "/arr/0", jsonpointer.Null},
+	}
+	for i, test := range tests {
+		fmt.Printf("=== RUN TestLookup #%d, pointer %s\n", i, test.ptr)
+		assert := require.New(t)
+		_, presence, err := jsonpointer.Lookup(test.src, test.ptr)
+		assert.NoError(err, "test %d", i)
+		assert.Equal(test.presence, presence, "test %d: %s", i, test.ptr)
+		assert.Equal(test.presence != jsonpointer.Absent, jsonpointer.Has(test.src, test.ptr), "test %d", i)
+		fmt.Println("--- PASS")
+	}
+}
+
+func TestLookupValue(t *testing.T) {
+	assert := require.New(t)
+	v, p, err := jsonpointer.Lookup([]byte(`{"foo":{"bar":"baz"}}`), "/foo/bar")
+	assert.NoError(err)
+	assert.Equal(jsonpointer.Present, p)
+	assert.Equal("baz", v)
+
+	v, p, err = jsonpointer.Lookup([]byte(`{"foo":{"bar":null}}`), "/foo/bar")
+	assert.NoError(err)
+	assert.Equal(jsonpointer.Null, p)
+	assert.Nil(v)
+
+	_, p, err = jsonpointer.Lookup([]byte(`{"foo":`), "/foo/bar")
+	assert.Error(err)
+	assert.Equal(jsonpointer.Absent, p)
+
+	_, _, err = jsonpointer.Lookup(nil, "foo")
+	assert.ErrorIs(err, jsonpointer.ErrMalformedStart)
+	assert.False(jsonpointer.Has(map[string]string{"foo": "bar"}, "foo"))
+
+	assert.Equal("present", jsonpointer.Present.String())
+	assert.True(jsonpointer.Null.IsNull())
+}
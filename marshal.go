@@ -0,0 +1,101 @@
+// Copyright 2022 Chance Dinkins
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+//
+// The License can be found in the LICENSE file.
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonpointer
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
+	"encoding/json"
+	"flag"
+	"fmt"
+)
+
+var (
+	_ encoding.TextMarshaler   = Pointer("")
+	_ encoding.TextUnmarshaler = (*Pointer)(nil)
+	_ json.Unmarshaler         = (*Pointer)(nil)
+	_ sql.Scanner              = (*Pointer)(nil)
+	_ driver.Valuer            = Pointer("")
+	_ flag.Value               = (*Pointer)(nil)
+)
+
+// MarshalText implements encoding.TextMarshaler by returning p as is.
+func (p Pointer) MarshalText() ([]byte, error) {
+	return []byte(p), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. The text is validated
+// with Validate before being assigned to p. Text which begins with '#' is
+// parsed as a URI fragment with ParseURIFragment.
+func (p *Pointer) UnmarshalText(text []byte) error {
+	var v Pointer
+	var err error
+	if len(text) > 0 && text[0] == '#' {
+		v, err = ParseURIFragment(string(text))
+	} else {
+		v = Pointer(text)
+		err = v.Validate()
+	}
+	if err != nil {
+		return err
+	}
+	*p = v
+	return nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler. The data must be either a JSON
+// string, which is validated with Validate, or null, which leaves p
+// unmodified.
+func (p *Pointer) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	return p.UnmarshalText([]byte(s))
+}
+
+// Scan implements sql.Scanner. The value must be a string or []byte, which
+// is unmarshaled with UnmarshalText, or nil (SQL NULL), which sets p to Root.
+func (p *Pointer) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*p = Root
+		return nil
+	case string:
+		return p.UnmarshalText([]byte(v))
+	case []byte:
+		return p.UnmarshalText(v)
+	default:
+		return fmt.Errorf("jsonpointer: unsupported type %T for Pointer.Scan", src)
+	}
+}
+
+// Value implements driver.Valuer. p is validated with Validate before being
+// returned as a string.
+func (p Pointer) Value() (driver.Value, error) {
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	return string(p), nil
+}
+
+// Set implements flag.Value. s is validated with Validate before being
+// assigned to p.
+func (p *Pointer) Set(s string) error {
+	return p.UnmarshalText([]byte(s))
+}
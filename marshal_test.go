@@ -0,0 +1,111 @@
+// Copyright 2022 Chance Dinkins
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+//
+// The License can be found in the LICENSE file.
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonpointer_test
+
+import (
+	"encoding/json"
+	"flag"
+	"testing"
+
+	"github.com/chanced/jsonpointer"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPointerJSON(t *testing.T) {
+	assert := require.New(t)
+	type Config struct {
+		Target jsonpointer.Pointer            `json:"target"`
+		Map    map[jsonpointer.Pointer]string `json:"map,omitempty"`
+	}
+
+	var c Config
+	err := json.Unmarshal([]byte(`{"target":"/foo/bar~1baz","map":{"/a":"b"}}`), &c)
+	assert.NoError(err)
+	assert.Equal(jsonpointer.Pointer("/foo/bar~1baz"), c.Target)
+	assert.Equal("b", c.Map["/a"])
+
+	b, err := json.Marshal(c)
+	assert.NoError(err)
+	assert.JSONEq(`{"target":"/foo/bar~1baz","map":{"/a":"b"}}`, string(b))
+
+	c = Config{Target: "/unchanged"}
+	err = json.Unmarshal([]byte(`{"target":null}`), &c)
+	assert.NoError(err)
+	assert.Equal(jsonpointer.Pointer("/unchanged"), c.Target)
+
+	err = json.Unmarshal([]byte(`{"target":"foo/bar"}`), &c)
+	assert.ErrorIs(err, jsonpointer.ErrMalformedStart)
+	assert.Equal(jsonpointer.Pointer("/unchanged"), c.Target)
+
+	err = json.Unmarshal([]byte(`{"target":"/foo~2"}`), &c)
+	assert.ErrorIs(err, jsonpointer.ErrMalformedEncoding)
+
+	err = json.Unmarshal([]byte(`{"map":{"foo":"bar"}}`), &c)
+	assert.ErrorIs(err, jsonpointer.ErrMalformedStart)
+
+	err = json.Unmarshal([]byte(`{"target":3}`), &c)
+	assert.Error(err)
+
+	// URI fragments are accepted
+	err = json.Unmarshal([]byte(`{"target":"#/definitions/a%20b"}`), &c)
+	assert.NoError(err)
+	assert.Equal(jsonpointer.Pointer("/definitions/a b"), c.Target)
+	err = json.Unmarshal([]byte(`{"target":"#/a%zz"}`), &c)
+	assert.Error(err)
+	assert.Equal(jsonpointer.Pointer("/definitions/a b"), c.Target)
+}
+
+func TestPointerScanValue(t *testing.T) {
+	assert := require.New(t)
+	var p jsonpointer.Pointer
+	assert.NoError(p.Scan("/foo"))
+	assert.Equal(jsonpointer.Pointer("/foo"), p)
+	assert.NoError(p.Scan([]byte("/bar")))
+	assert.Equal(jsonpointer.Pointer("/bar"), p)
+	assert.ErrorIs(p.Scan("bar"), jsonpointer.ErrMalformedStart)
+	assert.Error(p.Scan(3))
+	assert.Equal(jsonpointer.Pointer("/bar"), p)
+	assert.NoError(p.Scan("#/a%20b"))
+	assert.Equal(jsonpointer.Pointer("/a b"), p)
+	// SQL NULL is the root pointer
+	assert.NoError(p.Scan(nil))
+	assert.Equal(jsonpointer.Root, p)
+	assert.NoError(p.Scan("/bar"))
+
+	v, err := p.Value()
+	assert.NoError(err)
+	assert.Equal("/bar", v)
+
+	_, err = jsonpointer.Pointer("bar").Value()
+	assert.ErrorIs(err, jsonpointer.ErrMalformedStart)
+}
+
+func TestPointerFlag(t *testing.T) {
+	assert := require.New(t)
+	var p jsonpointer.Pointer
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.Var(&p, "ptr", "a json pointer")
+	assert.NoError(fs.Parse([]string{"-ptr", "/foo/0"}))
+	assert.Equal(jsonpointer.Pointer("/foo/0"), p)
+
+	fs = flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(nopWriter{})
+	fs.Var(&p, "ptr", "a json pointer")
+	assert.Error(fs.Parse([]string{"-ptr", "foo/0"}))
+	assert.Equal(jsonpointer.Pointer("/foo/0"), p)
+}
+
+type nopWriter struct{}
+
+func (nopWriter) Write(p []byte) (int, error) { return len(p), nil }
@@ -0,0 +1,219 @@
+// Copyright 2022 Chance Dinkins
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+//
+// The License can be found in the LICENSE file.
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonpointer
+
+import "encoding/json"
+
+// Codec encodes and decodes raw JSON. It is utilized whenever a value in the
+// path is a []byte or json.RawMessage, or when a value must be converted to
+// or from JSON to be assigned.
+//
+// The zero value of Options uses encoding/json.
+type Codec interface {
+	Marshal(v interface{}) ([]byte, error)
+	Unmarshal(data []byte, v interface{}) error
+}
+
+// Options configures the behavior of Resolve, Assign, and Delete.
+//
+// The zero value of Options matches the behavior of the package-level
+// functions when no Option is provided. NewOptions returns the recommended
+// defaults.
+type Options struct {
+	// StrictIndex requires tokens used as array or slice indexes to conform to
+	// the array-index grammar of RFC 6901: either "0" or a digit 1-9 followed
+	// by any number of digits. Tokens such as "+1", "01", and "-0" are
+	// rejected with ErrNonCanonicalIndex.
+	//
+	// When used with Pointer.Validate, tokens which would otherwise be parsed
+	// as a non-canonical index are reported.
+	StrictIndex bool
+
+	// StrictDash requires the token "-" to always refer to the nonexistent
+	// element after the last element of an array or slice, per RFC 6901.
+	// Resolving it results in ErrOutOfRange and assigning to it appends to
+	// slices.
+	//
+	// When false, "-" within an array refers to the first element of the
+	// trailing run of zero values, if any.
+	StrictDash bool
+
+	// CaseSensitive requires tokens to match struct field names exactly. If a
+	// token only matches a field case-insensitively, ErrNotFound is returned
+	// as a FieldError which suggests the token of that field.
+	//
+	// When false, a token which does not match a field name exactly is
+	// matched case-insensitively, as encoding/json does when unmarshaling.
+	CaseSensitive bool
+
+	// NoCreate prevents Assign from creating missing intermediate values,
+	// such as nil maps, slices, and pointers or absent map keys, along the
+	// path. Instead, ErrUnreachable is returned.
+	NoCreate bool
+
+	// TagKeys is an ordered list of struct tag keys used to determine the
+	// name of struct fields. The first key present on a field which provides
+	// a name is used; if the first key present is "-", the field is ignored.
+	// If empty, "json" is used.
+	TagKeys []string
+
+	// Codec is used to encode and decode raw JSON. If nil, encoding/json is
+	// used; when assigning to or deleting from raw JSON, numbers in the
+	// document are then decoded as json.Number so that they are re-encoded
+	// exactly. A custom Codec is
+	// responsible for preserving numbers itself.
+	Codec Codec
+
+	// PreserveFormat causes Assign and Delete to edit raw JSON by splicing
+	// only the bytes of the affected value, rather than decoding and
+	// re-encoding the whole of it. The order of members, whitespace, and the
+	// literals of untouched values are kept as they were; inserted members
+	// and elements follow the indentation of their siblings.
+	//
+	// Edits which can not be made by splicing, such as those through a null
+	// or primitive value, are made as if PreserveFormat were not set.
+	PreserveFormat bool
+}
+
+// Option is a functional option which configures Options.
+type Option func(*Options)
+
+// NewOptions returns Options with the recommended defaults, modified by opts.
+//
+// The defaults differ from the package-level functions, which retain the
+// behavior of prior releases unless configured otherwise:
+//
+// - StrictIndex is enabled
+//
+// - StrictDash is enabled
+func NewOptions(opts ...Option) Options {
+	o := Options{
+		StrictIndex: true,
+		StrictDash:  true,
+	}
+	return o.apply(opts)
+}
+
+// WithOptions replaces the current Options with o. Subsequent Option values
+// are applied to o.
+func WithOptions(o Options) Option {
+	return func(opts *Options) {
+		*opts = o
+	}
+}
+
+// WithStrictIndex configures whether array indexes must conform to the
+// array-index grammar of RFC 6901. See Options.StrictIndex.
+func WithStrictIndex(strict bool) Option {
+	return func(o *Options) {
+		o.StrictIndex = strict
+	}
+}
+
+// WithStrictDash configures whether "-" always refers to the element after
+// the last element of an array or slice. See Options.StrictDash.
+func WithStrictDash(strict bool) Option {
+	return func(o *Options) {
+		o.StrictDash = strict
+	}
+}
+
+// WithCaseSensitive configures whether tokens must match struct field names
+// exactly. See Options.CaseSensitive.
+func WithCaseSensitive(sensitive bool) Option {
+	return func(o *Options) {
+		o.CaseSensitive = sensitive
+	}
+}
+
+// WithCreateContainers configures whether Assign creates missing intermediate
+// values along the path. See Options.NoCreate.
+func WithCreateContainers(create bool) Option {
+	return func(o *Options) {
+		o.NoCreate = !create
+	}
+}
+
+// WithTagKey sets the struct tag key used to name struct fields. It is
+// equivalent to WithTagKeys(key).
+func WithTagKey(key string) Option {
+	return WithTagKeys(key)
+}
+
+// WithTagKeys sets the ordered list of struct tag keys used to name struct
+// fields, such as WithTagKeys("jsonpointer", "json"). See Options.TagKeys.
+func WithTagKeys(keys ...string) Option {
+	keys = append([]string(nil), keys...)
+	return func(o *Options) {
+		o.TagKeys = keys
+	}
+}
+
+// WithCodec sets the Codec used to encode and decode raw JSON. See
+// Options.Codec.
+func WithCodec(c Codec) Option {
+	return func(o *Options) {
+		o.Codec = c
+	}
+}
+
+// WithPreserveFormat configures whether Assign and Delete edit raw JSON in
+// place, preserving its formatting. See Options.PreserveFormat.
+func WithPreserveFormat(preserve bool) Option {
+	return func(o *Options) {
+		o.PreserveFormat = preserve
+	}
+}
+
+func (o Options) apply(opts []Option) Options {
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&o)
+		}
+	}
+	return o
+}
+
+// newOptions returns the Options of the package-level functions, modified by
+// opts.
+func newOptions(opts []Option) Options {
+	return Options{}.apply(opts)
+}
+
+var defaultTagKeys = []string{"json"}
+
+func (o Options) tagKeys() []string {
+	if len(o.TagKeys) == 0 {
+		return defaultTagKeys
+	}
+	return o.TagKeys
+}
+
+func (o Options) codec() Codec {
+	if o.Codec == nil {
+		return jsonCodec{}
+	}
+	return o.Codec
+}
+
+// jsonCodec is the Codec backed by encoding/json.
+type jsonCodec struct{}
+
+func (jsonCodec) Marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (jsonCodec) Unmarshal(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
@@ -0,0 +1,333 @@
+// Copyright 2022 Chance Dinkins
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+//
+// The License can be found in the LICENSE file.
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonpointer_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/chanced/jsonpointer"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewOptions(t *testing.T) {
+	assert := require.New(t)
+	assert.True(jsonpointer.NewOptions().StrictIndex)
+	assert.False(jsonpointer.NewOptions(jsonpointer.WithStrictIndex(false)).StrictIndex)
+
+	assert.True(jsonpointer.NewOptions().StrictDash)
+	assert.False(jsonpointer.NewOptions().CaseSensitive)
+	assert.False(jsonpointer.NewOptions().NoCreate)
+	assert.True(jsonpointer.NewOptions(jsonpointer.WithCreateContainers(false)).NoCreate)
+	assert.Equal([]string{"yaml"}, jsonpointer.NewOptions(jsonpointer.WithTagKey("yaml")).TagKeys)
+	assert.Equal([]string{"yaml", "json"}, jsonpointer.NewOptions(jsonpointer.WithTagKeys("yaml", "json")).TagKeys)
+
+	o := jsonpointer.NewOptions(jsonpointer.WithOptions(jsonpointer.Options{}))
+	assert.False(o.StrictIndex)
+	assert.False(o.StrictDash)
+}
+
+func TestStrictIndex(t *testing.T) {
+	assert := require.New(t)
+	r := Root{
+		Nested: Nested{
+			StrSlice: []string{"foo", "bar", "baz"},
+			IntArray: [3]int{30, 31, 32},
+			StrMap:   map[string]string{"01": "zero one"},
+		},
+	}
+
+	var s string
+	err := jsonpointer.Resolve(r, "/nested/strslice/01", &s)
+	assert.NoError(err)
+	assert.Equal("bar", s)
+
+	err = jsonpointer.Resolve(r, "/nested/strslice/01", &s, jsonpointer.WithStrictIndex(true))
+	assert.ErrorIs(err, jsonpointer.ErrNonCanonicalIndex)
+	assert.ErrorIs(err, jsonpointer.ErrMalformedIndex)
+
+	err = jsonpointer.Resolve(r, "/nested/strslice/+2", &s, jsonpointer.WithOptions(jsonpointer.NewOptions()))
+	assert.ErrorIs(err, jsonpointer.ErrNonCanonicalIndex)
+
+	var i int
+	err = jsonpointer.Resolve(r, "/nested/intarray/-0", &i, jsonpointer.WithStrictIndex(true))
+	assert.ErrorIs(err, jsonpointer.ErrNonCanonicalIndex)
+
+	// map keys are not indexes
+	err = jsonpointer.Resolve(r, "/nested/strmap/01", &s, jsonpointer.WithStrictIndex(true))
+	assert.NoError(err)
+	assert.Equal("zero one", s)
+
+	err = jsonpointer.Assign(&r, "/nested/strslice/01", "qux", jsonpointer.WithStrictIndex(true))
+	assert.ErrorIs(err, jsonpointer.ErrNonCanonicalIndex)
+	assert.Equal("bar", r.Nested.StrSlice[1])
+
+	err = jsonpointer.Delete(&r, "/nested/strslice/01", jsonpointer.WithStrictIndex(true))
+	assert.ErrorIs(err, jsonpointer.ErrNonCanonicalIndex)
+	assert.Len(r.Nested.StrSlice, 3)
+
+	var v interface{}
+	b := []byte(`{"foo":["bar","baz"]}`)
+	err = jsonpointer.Resolve(b, "/foo/01", &v, jsonpointer.WithStrictIndex(true))
+	assert.ErrorIs(err, jsonpointer.ErrNonCanonicalIndex)
+	err = jsonpointer.Resolve(b, "/foo/1", &v, jsonpointer.WithStrictIndex(true))
+	assert.NoError(err)
+	assert.Equal("baz", v)
+}
+
+func TestStrictDash(t *testing.T) {
+	assert := require.New(t)
+	r := Root{
+		Nested: Nested{
+			StrArray: [3]string{"foo", "", ""},
+			StrSlice: []string{"foo"},
+		},
+	}
+	var s string
+	err := jsonpointer.Resolve(r, "/nested/strarray/-", &s)
+	assert.NoError(err)
+
+	err = jsonpointer.Resolve(r, "/nested/strarray/-", &s, jsonpointer.WithStrictDash(true))
+	assert.ErrorIs(err, jsonpointer.ErrOutOfRange)
+	ie, ok := jsonpointer.AsIndexError(err)
+	assert.True(ok)
+	assert.Equal(3, ie.Index())
+
+	err = jsonpointer.Assign(&r, "/nested/strarray/-", "bar", jsonpointer.WithStrictDash(true))
+	assert.ErrorIs(err, jsonpointer.ErrOutOfRange)
+	assert.Equal([3]string{"foo", "", ""}, r.Nested.StrArray)
+
+	err = jsonpointer.Assign(&r, "/nested/strslice/-", "bar", jsonpointer.WithStrictDash(true))
+	assert.NoError(err)
+	assert.Equal([]string{"foo", "bar"}, r.Nested.StrSlice)
+}
+
+func TestCaseSensitive(t *testing.T) {
+	assert := require.New(t)
+	r := Root{Nested: Nested{Str: "strval"}}
+
+	var s string
+	err := jsonpointer.Resolve(r, "/Nested/STR", &s)
+	assert.NoError(err)
+	assert.Equal("strval", s)
+
+	err = jsonpointer.Resolve(r, "/Nested/STR", &s, jsonpointer.WithCaseSensitive(true))
+	assert.ErrorIs(err, jsonpointer.ErrNotFound)
+
+	err = jsonpointer.Assign(&r, "/nested/Str", "x", jsonpointer.WithCaseSensitive(true))
+	assert.ErrorIs(err, jsonpointer.ErrNotFound)
+	assert.Equal("strval", r.Nested.Str)
+}
+
+type caseFields struct {
+	ID    string `json:"id"`
+	Inner struct {
+		UserID string `json:"user~id"`
+	} `json:"inner"`
+}
+
+func TestCaseSensitiveSuggestion(t *testing.T) {
+	assert := require.New(t)
+	strict := jsonpointer.WithCaseSensitive(true)
+	v := caseFields{ID: "x"}
+
+	tests := []struct {
+		ptr        jsonpointer.Pointer
+		suggestion jsonpointer.Token
+		field      string
+	}{
+		{"/ID", "id", "ID"},
+		{"/Id", "id", "ID"},
+		{"/INNER/user~0id", "inner", "Inner"},
+		{"/inner/USER~0ID", "user~0id", "UserID"},
+	}
+	for i, test := range tests {
+		fmt.Printf("=== RUN TestCaseSensitiveSuggestion #%d, pointer %s\n", i, test.ptr)
+		var s string
+		err := jsonpointer.Resolve(v, test.ptr, &s, strict)
+		assert.ErrorIs(err, jsonpointer.ErrNotFound, "test %d", i)
+		fe, ok := jsonpointer.AsFieldError(err)
+		assert.True(ok, "test %d", i)
+		sug, ok := fe.Suggestion()
+		assert.True(ok, "test %d", i)
+		assert.Equal(test.suggestion, sug, "test %d", i)
+		assert.Equal(test.field, fe.Field().Name, "test %d", i)
+		assert.Contains(err.Error(), fmt.Sprintf(`did you mean "%s"?`, test.suggestion), "test %d", i)
+
+		err = jsonpointer.Assign(&v, test.ptr, "y", strict)
+		assert.ErrorIs(err, jsonpointer.ErrNotFound, "test %d", i)
+		fe, ok = jsonpointer.AsFieldError(err)
+		assert.True(ok, "test %d", i)
+		sug, _ = fe.Suggestion()
+		assert.Equal(test.suggestion, sug, "test %d", i)
+		fmt.Println("--- PASS")
+	}
+	assert.Equal("x", v.ID)
+
+	// no suggestion is made when nothing matches
+	var s string
+	err := jsonpointer.Resolve(v, "/name", &s, strict)
+	assert.ErrorIs(err, jsonpointer.ErrNotFound)
+	_, ok := jsonpointer.AsFieldError(err)
+	assert.False(ok)
+	assert.False(jsonpointer.Has(v, "/ID", strict))
+	assert.True(jsonpointer.Has(v, "/ID"))
+}
+
+func TestNoCreate(t *testing.T) {
+	assert := require.New(t)
+	noCreate := jsonpointer.WithCreateContainers(false)
+
+	var r Root
+	err := jsonpointer.Assign(&r, "/nestedptr/str", "x", noCreate)
+	assert.ErrorIs(err, jsonpointer.ErrUnreachable)
+	assert.Nil(r.NestedPtr)
+
+	err = jsonpointer.Assign(&r, "/nested/entrymap/foo/name", "x", noCreate)
+	assert.ErrorIs(err, jsonpointer.ErrUnreachable)
+	assert.Nil(r.Nested.EntryMap)
+
+	// leaf values may still be assigned
+	err = jsonpointer.Assign(&r, "/nested/str", "x", noCreate)
+	assert.NoError(err)
+	assert.Equal("x", r.Nested.Str)
+
+	r.Nested.StrMap = map[string]string{}
+	err = jsonpointer.Assign(&r, "/nested/strmap/foo", "bar", noCreate)
+	assert.NoError(err)
+	assert.Equal("bar", r.Nested.StrMap["foo"])
+
+	m := map[string]interface{}{}
+	err = jsonpointer.Assign(&m, "/foo/bar", "baz", noCreate)
+	assert.ErrorIs(err, jsonpointer.ErrUnreachable)
+	assert.Empty(m)
+
+	var rp *Root
+	err = jsonpointer.Assign(&rp, "/nested/str", "x", noCreate)
+	assert.ErrorIs(err, jsonpointer.ErrUnreachable)
+	assert.Nil(rp)
+
+	b := []byte{}
+	err = jsonpointer.Assign(&b, "/foo", "bar", noCreate)
+	assert.ErrorIs(err, jsonpointer.ErrUnreachable)
+	assert.Empty(b)
+}
+
+type yamlTagged struct {
+	Name string `json:"name" yaml:"full_name"`
+}
+
+func TestTagKey(t *testing.T) {
+	assert := require.New(t)
+	v := yamlTagged{Name: "foo"}
+
+	var s string
+	err := jsonpointer.Resolve(v, "/name", &s)
+	assert.NoError(err)
+	assert.Equal("foo", s)
+
+	err = jsonpointer.Resolve(v, "/full_name", &s, jsonpointer.WithTagKey("yaml"))
+	assert.NoError(err)
+	assert.Equal("foo", s)
+
+	err = jsonpointer.Resolve(v, "/full_name", &s)
+	assert.ErrorIs(err, jsonpointer.ErrNotFound)
+
+	err = jsonpointer.Assign(&v, "/full_name", "bar", jsonpointer.WithTagKey("yaml"))
+	assert.NoError(err)
+	assert.Equal("bar", v.Name)
+}
+
+type multiTagged struct {
+	Name    string `yaml:"full_name" json:"name"`
+	Email   string `yaml:",omitempty" json:"email_address"`
+	Secret  string `yaml:"-" json:"secret"`
+	Visible string `json:"-" yaml:"visible"`
+	Plain   string
+}
+
+func TestTagKeysFallback(t *testing.T) {
+	assert := require.New(t)
+	v := multiTagged{
+		Name:    "name",
+		Email:   "email",
+		Secret:  "secret",
+		Visible: "visible",
+		Plain:   "plain",
+	}
+
+	tests := []struct {
+		keys []string
+		ptr  jsonpointer.Pointer
+		val  string
+		err  error
+	}{
+		{[]string{"yaml", "json"}, "/full_name", "name", nil},
+		{[]string{"yaml", "json"}, "/name", "", jsonpointer.ErrNotFound},
+		{[]string{"yaml", "json"}, "/email_address", "email", nil},
+		{[]string{"yaml", "json"}, "/secret", "", jsonpointer.ErrNotFound},
+		{[]string{"yaml", "json"}, "/visible", "visible", nil},
+		{[]string{"yaml", "json"}, "/plain", "plain", nil},
+		{[]string{"json", "yaml"}, "/name", "name", nil},
+		{[]string{"json", "yaml"}, "/secret", "secret", nil},
+		{[]string{"json", "yaml"}, "/visible", "", jsonpointer.ErrNotFound},
+		{[]string{"bson"}, "/name", "name", nil},
+		{[]string{"bson"}, "/email", "email", nil},
+		{nil, "/email_address", "email", nil},
+	}
+	for i, test := range tests {
+		fmt.Printf("=== RUN TestTagKeysFallback #%d, keys %v, pointer %s\n", i, test.keys, test.ptr)
+		var s string
+		err := jsonpointer.Resolve(v, test.ptr, &s, jsonpointer.WithTagKeys(test.keys...))
+		if test.err != nil {
+			assert.ErrorIs(err, test.err, "test %d", i)
+		} else {
+			assert.NoError(err, "test %d", i)
+		}
+		assert.Equal(test.val, s, "test %d", i)
+		fmt.Println("--- PASS")
+	}
+}
+
+// upperCodec is a Codec which upper-cases every string it decodes.
+type upperCodec struct {
+	unmarshaled int
+}
+
+func (c *upperCodec) Marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (c *upperCodec) Unmarshal(data []byte, v interface{}) error {
+	c.unmarshaled++
+	return json.Unmarshal([]byte(strings.ToUpper(string(data))), v)
+}
+
+func TestCodec(t *testing.T) {
+	assert := require.New(t)
+	c := &upperCodec{}
+	b := []byte(`{"foo":"bar"}`)
+
+	var v interface{}
+	err := jsonpointer.Resolve(b, "/FOO", &v, jsonpointer.WithCodec(c))
+	assert.NoError(err)
+	assert.Equal("BAR", v)
+	assert.Equal(1, c.unmarshaled)
+
+	err = jsonpointer.Assign(&b, "/baz", "qux", jsonpointer.WithCodec(c))
+	assert.NoError(err)
+	assert.JSONEq(`{"FOO":"BAR","baz":"qux"}`, string(b))
+}
@@ -0,0 +1,332 @@
+// Copyright 2022 Chance Dinkins
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+//
+// The License can be found in the LICENSE file.
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonpointer
+
+import (
+	"encoding"
+	"reflect"
+	"sort"
+	"strconv"
+)
+
+const (
+	// WildcardToken is a Pattern token which matches exactly one token.
+	WildcardToken Token = "*"
+	// RecursiveWildcardToken is a Pattern token which matches zero or more
+	// tokens.
+	RecursiveWildcardToken Token = "**"
+)
+
+// A Pattern is a JSON Pointer which may contain wildcard tokens. A token of
+// "*" matches exactly one token while a token of "**" matches zero or more
+// tokens. All other tokens must match exactly.
+//
+// Note: there is no way to escape a wildcard. A Pattern of "/*" can not be
+// used to address a key of "*" exclusively.
+//
+// Examples:
+//
+//	"/items/*/name" matches "/items/0/name" and "/items/foo/name"
+//	"/**/id" matches "/id", "/a/id" and "/a/b/c/id"
+type Pattern string
+
+// PatternMatch is a concrete Pointer and its value, as found by
+// Pattern.Resolve.
+type PatternMatch struct {
+	Pointer Pointer
+	Value   interface{}
+}
+
+// ParsePattern accepts a string, trims any leading '#' and returns str as a
+// Pattern as well as any validation errors.
+func ParsePattern(pattern string) (Pattern, error) {
+	if len(pattern) > 0 && pattern[0] == '#' {
+		pattern = pattern[1:]
+	}
+	pt := Pattern(pattern)
+	return pt, pt.Validate()
+}
+
+func (pt Pattern) String() string {
+	return string(pt)
+}
+
+// Validate performs validation on pt. Patterns are subject to the same rules
+// as a Pointer. See Pointer.Validate.
+func (pt Pattern) Validate() error {
+	return Pointer(pt).Validate()
+}
+
+// IsLiteral returns true if pt does not contain any wildcard tokens.
+func (pt Pattern) IsLiteral() bool {
+	literal := true
+	Pointer(pt).Range(func(_ int, t Token) bool {
+		literal = !isWildcard(t)
+		return literal
+	})
+	return literal
+}
+
+// Match reports whether p matches pt. If it does, the tokens of p matched by
+// wildcards are returned in order. Each "*" captures exactly one token while
+// each "**" captures zero or more.
+func (pt Pattern) Match(p Pointer) (Tokens, bool) {
+	return matchTokens(pt.tokens(), Pointer(p).tokens(), Tokens{})
+}
+
+// Resolve performs resolution on src, returning each concrete Pointer which
+// matches pt along with its value. Matches are returned in depth-first order;
+// map keys are visited in sorted order and struct fields in the order of
+// their declaration.
+//
+// Paths which can not be resolved, such as those which lead to a missing key
+// or an out of range index, are omitted rather than reported as errors.
+// A []byte reached by a wildcard which does not hold JSON is treated as a
+// leaf value.
+//
+// Note: a Resolver within src is invoked with one token at a time.
+func (pt Pattern) Resolve(src interface{}, opts ...Option) ([]PatternMatch, error) {
+	s := newState(Pointer(pt), Resolving, newOptions(opts))
+	defer s.Release()
+	if err := pt.Validate(); err != nil {
+		return nil, newError(err, *s, reflect.TypeOf(src))
+	}
+	w := patternWalker{
+		s:    s,
+		seen: map[Pointer]struct{}{},
+	}
+	if err := w.walk(reflect.ValueOf(src), Root, pt.tokens()); err != nil {
+		return nil, err
+	}
+	return w.matches, nil
+}
+
+func (pt Pattern) tokens() []Token {
+	return Pointer(pt).tokens()
+}
+
+// tokens returns the encoded tokens of p.
+func (p Pointer) tokens() []Token {
+	tokens := make([]Token, 0, p.Len())
+	p.Range(func(_ int, t Token) bool {
+		tokens = append(tokens, t)
+		return true
+	})
+	return tokens
+}
+
+func isWildcard(t Token) bool {
+	return t == WildcardToken || t == RecursiveWildcardToken
+}
+
+func matchTokens(pattern []Token, tokens []Token, captured Tokens) (Tokens, bool) {
+	for len(pattern) > 0 {
+		switch pattern[0] {
+		case RecursiveWildcardToken:
+			for i := 0; i <= len(tokens); i++ {
+				c := append(captured[:len(captured):len(captured)], tokens[:i]...)
+				if res, ok := matchTokens(pattern[1:], tokens[i:], c); ok {
+					return res, true
+				}
+			}
+			return nil, false
+		case WildcardToken:
+			if len(tokens) == 0 {
+				return nil, false
+			}
+			captured = append(captured, tokens[0])
+		default:
+			if len(tokens) == 0 || tokens[0] != pattern[0] {
+				return nil, false
+			}
+		}
+		pattern, tokens = pattern[1:], tokens[1:]
+	}
+	if len(tokens) > 0 {
+		return nil, false
+	}
+	return captured, true
+}
+
+type patternWalker struct {
+	s       *state
+	seen    map[Pointer]struct{}
+	matches []PatternMatch
+	// wild is the number of wildcards being expanded
+	wild int
+}
+
+func (w *patternWalker) walk(v reflect.Value, p Pointer, pattern []Token) error {
+	if len(pattern) == 0 {
+		if _, ok := w.seen[p]; ok {
+			return nil
+		}
+		w.seen[p] = struct{}{}
+		m := PatternMatch{Pointer: p}
+		if v.IsValid() && v.CanInterface() {
+			m.Value = v.Interface()
+		}
+		w.matches = append(w.matches, m)
+		return nil
+	}
+	// raw JSON is decoded once rather than for each child
+	if v.IsValid() && isByteSlice(v) {
+		raw := v
+		if raw.Kind() == reflect.Interface {
+			raw = raw.Elem()
+		}
+		dv, err := w.s.unmarshal(raw)
+		if err != nil {
+			if w.wild > 0 {
+				// bytes reached by a wildcard which are not JSON are a leaf
+				return w.walkLeaf(v, p, pattern)
+			}
+			return err
+		}
+		if !dv.IsValid() {
+			return nil
+		}
+		v = dv
+	}
+	switch pattern[0] {
+	case RecursiveWildcardToken:
+		if err := w.walk(v, p, pattern[1:]); err != nil {
+			return err
+		}
+		return w.walkChildren(v, p, pattern)
+	case WildcardToken:
+		return w.walkChildren(v, p, pattern[1:])
+	default:
+		return w.walkToken(v, p, pattern[0], pattern[1:])
+	}
+}
+
+// walkLeaf matches v, which has no children, if the remainder of the pattern
+// consists only of recursive wildcards.
+func (w *patternWalker) walkLeaf(v reflect.Value, p Pointer, pattern []Token) error {
+	for _, t := range pattern {
+		if t != RecursiveWildcardToken {
+			return nil
+		}
+	}
+	return w.walk(v, p, nil)
+}
+
+func (w *patternWalker) walkChildren(v reflect.Value, p Pointer, pattern []Token) error {
+	tokens, err := w.children(v)
+	if err != nil {
+		return err
+	}
+	w.wild++
+	defer func() { w.wild-- }()
+	for _, t := range tokens {
+		if err = w.walkToken(v, p, t, pattern); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *patternWalker) walkToken(v reflect.Value, p Pointer, t Token, pattern []Token) error {
+	if isNil(v) {
+		return nil
+	}
+	w.s.current = Root
+	nv, err := w.s.resolveNext(v, t)
+	if err != nil {
+		if isNotFound(err) {
+			return nil
+		}
+		w.s.current = p.Append(t)
+		updateErrorState(err, *w.s)
+		return err
+	}
+	if !nv.IsValid() {
+		return nil
+	}
+	return w.walk(nv, p.Append(t), pattern)
+}
+
+// children returns the encoded tokens of each of the immediate children of v.
+func (w *patternWalker) children(v reflect.Value) ([]Token, error) {
+	for v.Kind() == reflect.Interface || v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil, nil
+		}
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.Map:
+		return mapTokens(v)
+	case reflect.Slice, reflect.Array:
+		tokens := make([]Token, v.Len())
+		for i := range tokens {
+			tokens[i] = Token(strconv.Itoa(i))
+		}
+		return tokens, nil
+	case reflect.Struct:
+		fields := cachedTypeFields(v.Type(), w.s.opts.tagKeys())
+		tokens := make([]Token, len(fields.list))
+		for i, f := range fields.list {
+			tokens[i] = Token(Encode(f.name))
+		}
+		return tokens, nil
+	default:
+		return nil, nil
+	}
+}
+
+func mapTokens(v reflect.Value) ([]Token, error) {
+	keys := v.MapKeys()
+	tokens := make([]Token, 0, len(keys))
+	for _, k := range keys {
+		var key string
+		switch {
+		case k.Type().Implements(typeTextMarshaler):
+			text, err := k.Interface().(encoding.TextMarshaler).MarshalText()
+			if err != nil {
+				return nil, err
+			}
+			key = string(text)
+		case k.Kind() == reflect.String:
+			key = k.String()
+		default:
+			switch k.Kind() {
+			case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+				key = strconv.FormatInt(k.Int(), 10)
+			case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+				key = strconv.FormatUint(k.Uint(), 10)
+			default:
+				continue
+			}
+		}
+		tokens = append(tokens, Token(Encode(key)))
+	}
+	sort.Slice(tokens, func(i, j int) bool {
+		return tokens[i] < tokens[j]
+	})
+	return tokens, nil
+}
+
+func isNil(v reflect.Value) bool {
+	if !v.IsValid() {
+		return true
+	}
+	switch v.Kind() {
+	case reflect.Interface, reflect.Ptr, reflect.Map, reflect.Slice:
+		return v.IsNil()
+	default:
+		return false
+	}
+}
@@ -0,0 +1,168 @@
+// Copyright 2022 Chance Dinkins
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+//
+// The License can be found in the LICENSE file.
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonpointer_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/chanced/jsonpointer"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPatternMatch(t *testing.T) {
+	assert := require.New(t)
+	tests := []struct {
+		pattern  jsonpointer.Pattern
+		ptr      jsonpointer.Pointer
+		match    bool
+		captured jsonpointer.Tokens
+	}{
+		{"", "", true, jsonpointer.Tokens{}},
+		{"/foo", "/foo", true, jsonpointer.Tokens{}},
+		{"/foo", "/foo/bar", false, nil},
+		{"/items/*/name", "/items/0/name", true, jsonpointer.Tokens{"0"}},
+		{"/items/*/name", "/items/a~1b/name", true, jsonpointer.Tokens{"a~1b"}},
+		{"/items/*/name", "/items/name", false, nil},
+		{"/items/*", "/items", false, nil},
+		{"/*/*", "/a/b", true, jsonpointer.Tokens{"a", "b"}},
+		{"/**/id", "/id", true, jsonpointer.Tokens{}},
+		{"/**/id", "/a/id", true, jsonpointer.Tokens{"a"}},
+		{"/**/id", "/a/b/c/id", true, jsonpointer.Tokens{"a", "b", "c"}},
+		{"/**/id", "/a/b/c", false, nil},
+		{"/**", "", true, jsonpointer.Tokens{}},
+		{"/**", "/a/b", true, jsonpointer.Tokens{"a", "b"}},
+		{"/a/**/b/*", "/a/x/b/y/b/z", true, jsonpointer.Tokens{"x", "b", "y", "z"}},
+		{"/a/**/b/*", "/a/b", false, nil},
+	}
+	for _, test := range tests {
+		captured, ok := test.pattern.Match(test.ptr)
+		assert.Equal(test.match, ok, "%q.Match(%q)", test.pattern, test.ptr)
+		assert.Equal(test.captured, captured, "%q.Match(%q)", test.pattern, test.ptr)
+	}
+}
+
+func TestParsePattern(t *testing.T) {
+	assert := require.New(t)
+	pt, err := jsonpointer.ParsePattern("#/items/*/name")
+	assert.NoError(err)
+	assert.Equal(jsonpointer.Pattern("/items/*/name"), pt)
+	assert.False(pt.IsLiteral())
+	assert.True(jsonpointer.Pattern("/items/0/name").IsLiteral())
+
+	_, err = jsonpointer.ParsePattern("items/*")
+	assert.ErrorIs(err, jsonpointer.ErrMalformedStart)
+}
+
+func TestPatternResolve(t *testing.T) {
+	type Item struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	}
+	type Doc struct {
+		ID    int             `json:"id"`
+		Items []Item          `json:"items"`
+		Named map[string]Item `json:"named"`
+		Ptr   *Item           `json:"ptr"`
+	}
+	doc := Doc{
+		ID:    1,
+		Items: []Item{{ID: 2, Name: "a"}, {ID: 3, Name: "b"}},
+		Named: map[string]Item{"y": {ID: 5, Name: "d"}, "x": {ID: 4, Name: "c"}},
+	}
+	raw, err := json.Marshal(doc)
+	require.NoError(t, err)
+
+	tests := []struct {
+		pattern  jsonpointer.Pattern
+		expected []jsonpointer.PatternMatch
+	}{
+		{"/items/*/name", []jsonpointer.PatternMatch{
+			{Pointer: "/items/0/name", Value: "a"},
+			{Pointer: "/items/1/name", Value: "b"},
+		}},
+		{"/named/*/id", []jsonpointer.PatternMatch{
+			{Pointer: "/named/x/id", Value: 4},
+			{Pointer: "/named/y/id", Value: 5},
+		}},
+		{"/**/id", []jsonpointer.PatternMatch{
+			{Pointer: "/id", Value: 1},
+			{Pointer: "/items/0/id", Value: 2},
+			{Pointer: "/items/1/id", Value: 3},
+			{Pointer: "/named/x/id", Value: 4},
+			{Pointer: "/named/y/id", Value: 5},
+		}},
+		{"/**/**/id", []jsonpointer.PatternMatch{
+			{Pointer: "/id", Value: 1},
+			{Pointer: "/items/0/id", Value: 2},
+			{Pointer: "/items/1/id", Value: 3},
+			{Pointer: "/named/x/id", Value: 4},
+			{Pointer: "/named/y/id", Value: 5},
+		}},
+		{"/items/5/*", nil},
+		{"/ptr/*", nil},
+		{"/missing/*", nil},
+		{"/id", []jsonpointer.PatternMatch{{Pointer: "/id", Value: 1}}},
+	}
+	for _, test := range tests {
+		fmt.Printf("=== RUN TestPatternResolve, pattern %s\n", test.pattern)
+		assert := require.New(t)
+		matches, err := test.pattern.Resolve(doc)
+		assert.NoError(err)
+		assert.Equal(test.expected, matches)
+
+		// raw JSON yields the same pointers with float64 values
+		matches, err = test.pattern.Resolve(raw)
+		assert.NoError(err)
+		assert.Len(matches, len(test.expected))
+		for i, m := range matches {
+			assert.Equal(test.expected[i].Pointer, m.Pointer)
+			var v interface{}
+			assert.NoError(jsonpointer.Resolve(raw, m.Pointer, &v))
+			assert.Equal(v, m.Value)
+		}
+		fmt.Println("--- PASS")
+	}
+
+	_, err = jsonpointer.Pattern("items/*").Resolve(doc)
+	assert := require.New(t)
+	assert.ErrorIs(err, jsonpointer.ErrMalformedStart)
+
+	_, err = jsonpointer.Pattern("/*").Resolve([]byte(`{"invalid`))
+	assert.Error(err)
+
+	// bytes which are not JSON are a leaf when reached by a wildcard
+	type Blob struct {
+		Name string `json:"name"`
+		Data []byte `json:"data"`
+	}
+	blobs := []Blob{{Name: "a", Data: []byte("not json")}, {Name: "b", Data: []byte(`{"name":"c"}`)}}
+	matches, err := jsonpointer.Pattern("/**/name").Resolve(blobs)
+	assert.NoError(err)
+	assert.Equal([]jsonpointer.PatternMatch{
+		{Pointer: "/0/name", Value: "a"},
+		{Pointer: "/1/name", Value: "b"},
+		{Pointer: "/1/data/name", Value: "c"},
+	}, matches)
+
+	matches, err = jsonpointer.Pattern("/*/data/**").Resolve(blobs)
+	assert.NoError(err)
+	assert.Len(matches, 3)
+	assert.Equal(jsonpointer.Pointer("/0/data"), matches[0].Pointer)
+	assert.Equal([]byte("not json"), matches[0].Value)
+
+	_, err = jsonpointer.Pattern("/0/data/name").Resolve(blobs)
+	assert.Error(err)
+}
@@ -0,0 +1,159 @@
+// Copyright 2022 Chance Dinkins
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+//
+// The License can be found in the LICENSE file.
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonpointer
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"math"
+	"reflect"
+	"strconv"
+)
+
+// isReader reports whether v should be read as a stream of JSON, which is
+// the case if it implements io.Reader but not Resolver or ContextResolver.
+func isReader(v reflect.Value) bool {
+	if !v.IsValid() {
+		return false
+	}
+	t := v.Type()
+	return t.Implements(typeReader) && !t.Implements(typeResolver) && !t.Implements(typeContextResolver)
+}
+
+// resolveReader resolves s.current from the JSON read from r, reading only as
+// far as necessary to find the referenced value. Values which precede it are
+// skipped rather than decoded. The value is returned as by rawValue.
+//
+// Unlike raw JSON held in memory, and encoding/json, which use the last, the
+// first member of an object with the name of a token is used, regardless of
+// whether the name is repeated. This allows the value to be resolved without
+// reading the remainder of the object.
+//
+// If s.opts.Codec is set, r is read in full and resolved as raw JSON.
+func (s *state) resolveReader(r io.Reader) (reflect.Value, error) {
+	typ := reflect.TypeOf(r)
+	if s.opts.Codec != nil {
+		b, err := io.ReadAll(r)
+		if err != nil {
+			return reflect.Value{}, newError(err, *s, typ)
+		}
+		return s.resolve(reflect.ValueOf(b))
+	}
+	dec := json.NewDecoder(r)
+	// container is the type of the decoded value which holds the current value
+	container := typ
+	for !s.current.IsRoot() {
+		if err := s.ctx.Err(); err != nil {
+			return reflect.Value{}, newError(err, *s, typ)
+		}
+		tok, err := dec.Token()
+		if err != nil {
+			return reflect.Value{}, newError(err, *s, typ)
+		}
+		cur := s.current
+		next, t, _ := cur.Next()
+		var found bool
+		switch tok {
+		case json.Delim('{'):
+			if found, err = s.streamMember(dec, t); err == nil && !found {
+				err = ErrNotFound
+			}
+			if err != nil {
+				return reflect.Value{}, newError(err, *s, typeAnyMap)
+			}
+			container = typeAnyMap
+		case json.Delim('['):
+			if err = s.streamElement(dec, t); err != nil {
+				return reflect.Value{}, newError(err, *s, typeAnySlice)
+			}
+			container = typeAnySlice
+		case nil:
+			return reflect.Value{}, newError(ErrUnreachable, *s, container)
+		default:
+			// a primitive, which can not be traversed; it is resolved as it
+			// would be once decoded
+			var x interface{} = tok
+			return s.resolve(reflect.ValueOf(&x).Elem())
+		}
+		s.current = next
+	}
+	var raw json.RawMessage
+	if err := dec.Decode(&raw); err != nil {
+		return reflect.Value{}, newError(err, *s, typ)
+	}
+	v := rawValue(raw)
+	if !s.ptr.IsRoot() && isNil(v) {
+		// consistent with resolving a null member or element once decoded
+		return v, newError(ErrUnreachable, *s, container)
+	}
+	return v, nil
+}
+
+// streamMember advances dec, which has read the opening delimiter of an
+// object, to the value of the member named by t. found is false if the object
+// has no such member.
+func (s *state) streamMember(dec *json.Decoder, t Token) (found bool, err error) {
+	key := t.String()
+	var skip json.RawMessage
+	for dec.More() {
+		k, err := dec.Token()
+		if err != nil {
+			return false, err
+		}
+		if k == key {
+			return true, nil
+		}
+		if err = dec.Decode(&skip); err != nil {
+			return false, err
+		}
+	}
+	_, err = dec.Token()
+	return false, err
+}
+
+// streamElement advances dec, which has read the opening delimiter of an
+// array, to the element at the index t. An error is returned, as it would be
+// for a decoded slice, if t is not an index of the array.
+func (s *state) streamElement(dec *json.Decoder, t Token) error {
+	i := -1
+	if t != "-" {
+		i, _ = s.index(t, math.MaxInt)
+	}
+	var skip json.RawMessage
+	n := 0
+	for ; dec.More(); n++ {
+		if n == i {
+			return nil
+		}
+		if err := dec.Decode(&skip); err != nil {
+			return err
+		}
+	}
+	if _, err := dec.Token(); err != nil {
+		return err
+	}
+	i, err := s.index(t, n)
+	if err != nil {
+		if errors.Is(err, strconv.ErrSyntax) {
+			return ErrMalformedIndex
+		}
+		return err
+	}
+	return &indexError{
+		err:      ErrOutOfRange,
+		maxIndex: n - 1,
+		index:    i,
+	}
+}
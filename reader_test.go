@@ -0,0 +1,140 @@
+// Copyright 2022 Chance Dinkins
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+//
+// The License can be found in the LICENSE file.
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonpointer_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/chanced/jsonpointer"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveReader(t *testing.T) {
+	assert := require.New(t)
+
+	tests := []jsonpointer.Pointer{
+		"/str",
+		"/esc\"aped",
+		"/sl~1ash",
+		"/num",
+		"/null",
+		"/null/x",
+		"/empty",
+		"/empty/x",
+		"/missing",
+		"/arr/0/a/2/b",
+		"/arr/1",
+		"/arr/2",
+		"/arr/3/0",
+		"/arr/4",
+		"/arr/-",
+		"/arr/x",
+		"/obj/nested/deep/1",
+		"/str/x",
+		"/num/0",
+	}
+
+	for i, ptr := range tests {
+		fmt.Printf("=== RUN TestResolveReader #%d, pointer %s\n", i, ptr)
+		var expected interface{}
+		expectedErr := jsonpointer.Resolve([]byte(scanDoc), ptr, &expected)
+		var v interface{}
+		err := jsonpointer.Resolve(strings.NewReader(scanDoc), ptr, &v)
+		assert.Equal(expected, v, "test %d", i)
+		if expectedErr != nil {
+			assert.Error(err, "test %d", i)
+			assert.Equal(errorChain(expectedErr), errorChain(err), "test %d", i)
+			e1, _ := jsonpointer.AsError(expectedErr)
+			e2, ok := jsonpointer.AsError(err)
+			assert.True(ok, "test %d", i)
+			assert.Equal(e1.CurrentJSONPointer(), e2.CurrentJSONPointer(), "test %d", i)
+		} else {
+			assert.NoError(err, "test %d", i)
+		}
+		fmt.Println("--- PASS")
+	}
+}
+
+// failingReader returns err once the reads of r are exhausted.
+type failingReader struct {
+	r   io.Reader
+	err error
+}
+
+func (f failingReader) Read(p []byte) (int, error) {
+	n, err := f.r.Read(p)
+	if err == io.EOF {
+		return n, f.err
+	}
+	return n, err
+}
+
+func TestResolveReaderPartial(t *testing.T) {
+	assert := require.New(t)
+	errStream := errors.New("stream closed")
+	r := failingReader{r: strings.NewReader(`{"skip": [1, {"x": "y"}], "a": {"b": 1}, "c": `), err: errStream}
+
+	// the stream is not read beyond the value, so the error is not reached
+	var i int
+	err := jsonpointer.Resolve(r, "/a/b", &i)
+	assert.NoError(err)
+	assert.Equal(1, i)
+
+	r = failingReader{r: strings.NewReader(`{"skip": [1, {"x": "y"}], "a": {"b": 1}, "c": `), err: errStream}
+	err = jsonpointer.Resolve(r, "/c", &i)
+	assert.ErrorIs(err, errStream)
+
+	var raw json.RawMessage
+	err = jsonpointer.Resolve(strings.NewReader(`{"a": [ {"b": true} ]}`), "/a", &raw)
+	assert.NoError(err)
+	assert.Equal(`[ {"b": true} ]`, string(raw))
+
+	var tmpl template
+	err = jsonpointer.Resolve(strings.NewReader(`{"spec":{"template":{"name":"web","ports":[80]}}}`), "/spec/template", &tmpl)
+	assert.NoError(err)
+	assert.Equal(template{Name: "web", Ports: []int{80}}, tmpl)
+
+	var root map[string]interface{}
+	err = jsonpointer.Resolve(strings.NewReader(`{"a": [1]}`), "", &root)
+	assert.NoError(err)
+	assert.Equal(map[string]interface{}{"a": []interface{}{float64(1)}}, root)
+
+	// the first of repeated members is used, unlike raw JSON in memory
+	dups := `{"a": "first", "a": "second"}`
+	var s string
+	err = jsonpointer.Resolve(strings.NewReader(dups), "/a", &s)
+	assert.NoError(err)
+	assert.Equal("first", s)
+	err = jsonpointer.Resolve([]byte(dups), "/a", &s)
+	assert.NoError(err)
+	assert.Equal("second", s)
+
+	err = jsonpointer.Resolve(strings.NewReader(`null`), "/a", &i)
+	assert.ErrorIs(err, jsonpointer.ErrUnreachable)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = jsonpointer.ResolveContext(ctx, strings.NewReader(`{"a": 1}`), "/a", &i)
+	assert.ErrorIs(err, context.Canceled)
+
+	err = jsonpointer.Resolve(strings.NewReader(`{"a": 1}`), "/a", &i, jsonpointer.WithCodec(decodeCodec{}))
+	assert.NoError(err)
+	assert.Equal(1, i)
+}
@@ -0,0 +1,251 @@
+// Copyright 2022 Chance Dinkins
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+//
+// The License can be found in the LICENSE file.
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonpointer
+
+import (
+	"bytes"
+	"reflect"
+	"strconv"
+)
+
+// A RelativePointer is a Relative JSON Pointer, consisting of a non-negative
+// integer indicating how many levels to move up from a base Pointer, an
+// optional index manipulation ("+1", "-2") and either a Pointer to resolve
+// from that location or '#', which references the key or index of the
+// location itself.
+//
+// See [Relative JSON Pointers](https://datatracker.ietf.org/doc/html/draft-bhutton-relative-json-pointer-00)
+// for more information.
+//
+// Examples, with a base of "/foo/1":
+//
+//	"0"          => "/foo/1"
+//	"1/0"        => "/foo/0"
+//	"0-1"        => "/foo/0"
+//	"2/highly"   => "/highly"
+//	"0#"         => 1
+//	"1#"         => "foo"
+type RelativePointer string
+
+// ParseRelative accepts a string and returns it as a RelativePointer as well as
+// any validation errors.
+func ParseRelative(ptr string) (RelativePointer, error) {
+	rp := RelativePointer(ptr)
+	return rp, rp.Validate()
+}
+
+func (rp RelativePointer) String() string {
+	return string(rp)
+}
+
+// Validate performs validation on rp. The following checks are performed:
+//
+// - rp must start with a non-negative integer without leading zeros
+//
+// - the integer may be followed by an index manipulation, a '+' or '-' and a
+// non-negative integer without leading zeros
+//
+// - the remainder must be either "#" or a valid Pointer
+func (rp RelativePointer) Validate() error {
+	_, err := rp.parse()
+	return err
+}
+
+// IsKeyReference returns true if rp ends with '#', meaning it references the
+// key or index of the location rather than the value.
+func (rp RelativePointer) IsKeyReference() bool {
+	r, err := rp.parse()
+	return err == nil && r.hash
+}
+
+// Pointer evaluates rp against base and returns the resulting Pointer. If rp
+// is a key reference ("#"), the returned Pointer is that of the location whose
+// key or index is referenced.
+//
+// Pointer does not check whether the location exists, nor whether an index
+// manipulation is applied to an array element. Use Resolve for that.
+func (rp RelativePointer) Pointer(base Pointer) (Pointer, error) {
+	r, err := rp.parse()
+	if err != nil {
+		return Root, err
+	}
+	p, err := r.origin(base)
+	if err != nil {
+		return Root, err
+	}
+	if r.hash {
+		return p, nil
+	}
+	return p + r.ptr, nil
+}
+
+// Resolve evaluates rp against base, performing resolution on src and
+// assigning the value to dst.
+//
+// If rp is a key reference ("#"), dst is assigned the index (int) of the
+// location if its parent is an array or the key (string) of the location
+// otherwise.
+//
+// opts are passed along to Resolve.
+func (rp RelativePointer) Resolve(src interface{}, base Pointer, dst interface{}, opts ...Option) error {
+	r, err := rp.parse()
+	if err != nil {
+		return err
+	}
+	if err = base.Validate(); err != nil {
+		return err
+	}
+	p, err := r.origin(base)
+	if err != nil {
+		return relativeError(err, base, src)
+	}
+	if r.shift == 0 && !r.hash {
+		return Resolve(src, p+r.ptr, dst, opts...)
+	}
+	parent, t, _ := p.Pop()
+	isArray, err := isArrayAt(src, parent, opts)
+	if err != nil {
+		return err
+	}
+	if r.shift != 0 {
+		if !isArray {
+			return relativeError(ErrRelativeOrigin, p, src)
+		}
+		i, err := t.Int()
+		if err != nil {
+			return relativeError(ErrRelativeOrigin, p, src)
+		}
+		i += r.shift
+		if i < 0 {
+			return relativeError(ErrRelativeOrigin, p, src)
+		}
+		t = Token(strconv.Itoa(i))
+		p = parent.Append(t)
+	}
+	if !r.hash {
+		return Resolve(src, p+r.ptr, dst, opts...)
+	}
+	var v interface{}
+	// the location must exist for its key or index to be referenced
+	if err = Resolve(src, p, &v, opts...); err != nil {
+		return err
+	}
+	if isArray {
+		i, err := t.Int()
+		if err != nil {
+			return relativeError(ErrMalformedIndex, p, src)
+		}
+		return Resolve(i, Root, dst)
+	}
+	return Resolve(t.String(), Root, dst)
+}
+
+type relative struct {
+	up    int
+	shift int
+	hash  bool
+	ptr   Pointer
+}
+
+// origin moves up r.up levels from base.
+func (r relative) origin(base Pointer) (Pointer, error) {
+	p := base
+	for i := 0; i < r.up; i++ {
+		var ok bool
+		if p, _, ok = p.Pop(); !ok {
+			return Root, ErrRelativeOrigin
+		}
+	}
+	if (r.hash || r.shift != 0) && p.IsRoot() {
+		return Root, ErrRelativeOrigin
+	}
+	return p, nil
+}
+
+func (rp RelativePointer) parse() (relative, error) {
+	var r relative
+	s := string(rp)
+	up, n, ok := parseNonNegativeInt(s)
+	if !ok {
+		return r, ErrMalformedRelativePointer
+	}
+	r.up = up
+	s = s[n:]
+	if len(s) > 0 && (s[0] == '+' || s[0] == '-') {
+		shift, n, ok := parseNonNegativeInt(s[1:])
+		if !ok {
+			return r, ErrMalformedRelativePointer
+		}
+		if s[0] == '-' {
+			shift = -shift
+		}
+		r.shift = shift
+		s = s[n+1:]
+	}
+	if s == "#" {
+		r.hash = true
+		return r, nil
+	}
+	r.ptr = Pointer(s)
+	if err := r.ptr.Validate(); err != nil {
+		return r, err
+	}
+	return r, nil
+}
+
+// parseNonNegativeInt parses the leading non-negative integer of s, returning
+// the value and the number of bytes consumed. Leading zeros are not permitted.
+func parseNonNegativeInt(s string) (int, int, bool) {
+	n := 0
+	for n < len(s) && '0' <= s[n] && s[n] <= '9' {
+		n++
+	}
+	if n == 0 || (n > 1 && s[0] == '0') {
+		return 0, 0, false
+	}
+	i, err := strconv.Atoi(s[:n])
+	if err != nil {
+		return 0, 0, false
+	}
+	return i, n, true
+}
+
+// isArrayAt reports whether the value of src referenced by ptr is an array,
+// slice, or raw JSON array.
+func isArrayAt(src interface{}, ptr Pointer, opts []Option) (bool, error) {
+	var v interface{}
+	if err := Resolve(src, ptr, &v, opts...); err != nil {
+		return false, err
+	}
+	rv := reflect.ValueOf(v)
+	if !rv.IsValid() {
+		return false, nil
+	}
+	if isByteSlice(rv) {
+		b := bytes.TrimLeft(rv.Bytes(), " \t\r\n")
+		return len(b) > 0 && b[0] == '[', nil
+	}
+	switch rv.Kind() {
+	case reflect.Array, reflect.Slice:
+		return true, nil
+	default:
+		return false, nil
+	}
+}
+
+func relativeError(err error, ptr Pointer, src interface{}) error {
+	s := newState(ptr, Resolving, Options{})
+	defer s.Release()
+	return newError(err, *s, reflect.TypeOf(src))
+}
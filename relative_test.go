@@ -0,0 +1,174 @@
+// Copyright 2022 Chance Dinkins
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+//
+// The License can be found in the LICENSE file.
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonpointer_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/chanced/jsonpointer"
+	"github.com/stretchr/testify/require"
+)
+
+const relativeDoc = `{
+	"foo": ["bar", "baz"],
+	"highly": {
+		"nested": {
+			"objects": true
+		}
+	}
+}`
+
+func TestRelativePointerValidate(t *testing.T) {
+	assert := require.New(t)
+	tests := []struct {
+		ptr jsonpointer.RelativePointer
+		err error
+	}{
+		{"0", nil},
+		{"1/0", nil},
+		{"0-1", nil},
+		{"0+12/foo", nil},
+		{"2/highly/nested/objects", nil},
+		{"0#", nil},
+		{"10#", nil},
+		{"", jsonpointer.ErrMalformedRelativePointer},
+		{"/foo", jsonpointer.ErrMalformedRelativePointer},
+		{"01", jsonpointer.ErrMalformedRelativePointer},
+		{"0+", jsonpointer.ErrMalformedRelativePointer},
+		{"0-01", jsonpointer.ErrMalformedRelativePointer},
+		{"0foo", jsonpointer.ErrMalformedStart},
+		{"0/foo~", jsonpointer.ErrMalformedEncoding},
+		{"0##", jsonpointer.ErrMalformedStart},
+	}
+	for _, test := range tests {
+		_, err := jsonpointer.ParseRelative(string(test.ptr))
+		if test.err != nil {
+			assert.ErrorIs(err, test.err, "relative pointer %q", test.ptr)
+			assert.ErrorIs(err, jsonpointer.ErrMalformedToken, "relative pointer %q", test.ptr)
+		} else {
+			assert.NoError(err, "relative pointer %q", test.ptr)
+		}
+	}
+}
+
+func TestRelativePointerPointer(t *testing.T) {
+	assert := require.New(t)
+	tests := []struct {
+		base     jsonpointer.Pointer
+		rel      jsonpointer.RelativePointer
+		expected jsonpointer.Pointer
+		err      error
+	}{
+		{"/foo/1", "0", "/foo/1", nil},
+		{"/foo/1", "1/0", "/foo/0", nil},
+		{"/foo/1", "2/highly/nested/objects", "/highly/nested/objects", nil},
+		{"/foo/1", "0#", "/foo/1", nil},
+		{"/foo/1", "2#", "", jsonpointer.ErrRelativeOrigin},
+		{"/foo/1", "3", "", jsonpointer.ErrRelativeOrigin},
+		{"", "0", "", nil},
+	}
+	for _, test := range tests {
+		p, err := test.rel.Pointer(test.base)
+		if test.err != nil {
+			assert.ErrorIs(err, test.err)
+			continue
+		}
+		assert.NoError(err)
+		assert.Equal(test.expected, p)
+	}
+}
+
+func TestRelativePointerResolve(t *testing.T) {
+	var doc map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(relativeDoc), &doc))
+
+	tests := []struct {
+		base     jsonpointer.Pointer
+		rel      jsonpointer.RelativePointer
+		expected interface{}
+		err      error
+	}{
+		{"/foo/1", "0", "baz", nil},
+		{"/foo/1", "1/0", "bar", nil},
+		{"/foo/1", "0-1", "bar", nil},
+		{"/foo/1", "2/highly/nested/objects", true, nil},
+		{"/foo/1", "0#", 1, nil},
+		{"/foo/1", "0-1#", 0, nil},
+		{"/foo/1", "1#", "foo", nil},
+		{"/highly/nested", "0/objects", true, nil},
+		{"/highly/nested", "1/nested/objects", true, nil},
+		{"/highly/nested", "2/foo/0", "bar", nil},
+		{"/highly/nested", "0#", "nested", nil},
+		{"/highly/nested", "1#", "highly", nil},
+		{"/foo/1", "0+1", nil, jsonpointer.ErrOutOfRange},
+		{"/foo/1", "0+1#", nil, jsonpointer.ErrOutOfRange},
+		{"/foo/1", "0-2", nil, jsonpointer.ErrRelativeOrigin},
+		{"/foo/1", "2#", nil, jsonpointer.ErrRelativeOrigin},
+		{"/foo/1", "3", nil, jsonpointer.ErrRelativeOrigin},
+		{"/highly/nested", "0+1#", nil, jsonpointer.ErrRelativeOrigin},
+	}
+	sources := map[string]interface{}{
+		"map": doc,
+		"raw": []byte(relativeDoc),
+	}
+	for name, src := range sources {
+		for _, test := range tests {
+			fmt.Printf("=== RUN TestRelativePointerResolve %s, base %s, relative %s\n", name, test.base, test.rel)
+			assert := require.New(t)
+			var v interface{}
+			err := test.rel.Resolve(src, test.base, &v)
+			if test.err != nil {
+				assert.ErrorIs(err, test.err)
+				continue
+			}
+			assert.NoError(err)
+			assert.Equal(test.expected, v)
+			fmt.Println("--- PASS")
+		}
+	}
+}
+
+func TestRelativePointerResolveStruct(t *testing.T) {
+	assert := require.New(t)
+	type Item struct {
+		Name string `json:"name"`
+	}
+	type Doc struct {
+		Items []Item `json:"items"`
+	}
+	doc := Doc{Items: []Item{{Name: "first"}, {Name: "second"}}}
+
+	var name string
+	err := jsonpointer.RelativePointer("1/name").Resolve(doc, "/items/1/name", &name)
+	assert.NoError(err)
+	assert.Equal("second", name)
+
+	err = jsonpointer.RelativePointer("1-1/name").Resolve(doc, "/items/1/name", &name)
+	assert.NoError(err)
+	assert.Equal("first", name)
+
+	var i int
+	err = jsonpointer.RelativePointer("1#").Resolve(doc, "/items/1/name", &i)
+	assert.NoError(err)
+	assert.Equal(1, i)
+
+	err = jsonpointer.RelativePointer("0#").Resolve(doc, "/items/1/name", &name)
+	assert.NoError(err)
+	assert.Equal("name", name)
+
+	err = jsonpointer.RelativePointer("0#").Resolve(doc, "/items/1/name", &i)
+	assert.ErrorIs(err, jsonpointer.ErrNotAssignable)
+}
@@ -14,7 +14,8 @@
 package jsonpointer
 
 import (
-	"encoding/json"
+	"context"
+	"io"
 	"reflect"
 )
 
@@ -24,29 +25,107 @@ type Resolver interface {
 	ResolveJSONPointer(ptr *Pointer, op Operation) (interface{}, error)
 }
 
+// ContextResolver is a Resolver which is provided the context.Context of the
+// operation. If a type implements both ContextResolver and Resolver,
+// ResolveJSONPointerContext is preferred.
+type ContextResolver interface {
+	ResolveJSONPointerContext(ctx context.Context, ptr *Pointer, op Operation) (interface{}, error)
+}
+
 // Resolve performs resolution on src by traversing the path of the JSON Pointer
 // and assigning the value to dst. If the path can not be reached, an error is
 // returned.
-func Resolve(src interface{}, ptr Pointer, dst interface{}) error {
+//
+// If the resolved value is not assignable to dst, it is converted when
+// possible: numbers are converted to other numeric types (including
+// json.Number) when no information is lost, returning ErrOverflow if out of
+// range; strings are parsed into time.Duration or unmarshaled into types which
+// implement encoding.TextUnmarshaler, such as time.Time. Generic JSON values
+// (map[string]interface{} and []interface{}) are decoded into dst, allowing
+// Resolve to be used to partially unmarshal raw JSON.
+//
+// Raw JSON in src is scanned for the referenced value rather than decoded in
+// full. The document is still validated in full, so invalid JSON anywhere in
+// src is reported as it would be when decoding. If dst is a *[]byte or
+// *json.RawMessage, it is set to the original bytes of the value, a subslice
+// of src.
+//
+// If src is an io.Reader (which does not implement Resolver), JSON is read from
+// it only until the referenced value has been read; values which precede it
+// are skipped rather than decoded. If an object repeats the name of a member,
+// the first is used, whereas the last is used for raw JSON in memory. Note
+// that src may be read beyond the value, as reads are buffered.
+//
+// If dst is an io.Writer, such as an http.ResponseWriter, the JSON encoding of
+// the value is written to it rather than assigned. When src is raw JSON, the
+// original bytes of the value are written.
+//
+// The behavior of Resolve can be configured with opts. See Options.
+func Resolve(src interface{}, ptr Pointer, dst interface{}, opts ...Option) error {
+	return ResolveContext(context.Background(), src, ptr, dst, opts...)
+}
+
+// ResolveContext is Resolve with a context.Context which is passed to any
+// ContextResolver in the path. ctx is checked for cancellation between tokens;
+// if it is done, the returned error wraps ctx.Err().
+func ResolveContext(ctx context.Context, src interface{}, ptr Pointer, dst interface{}, opts ...Option) error {
 	dv := reflect.ValueOf(dst)
-	s := newState(ptr, Resolving)
+	s := newState(ptr, Resolving, newOptions(opts))
 	defer s.Release()
+	s.ctx = ctx
 	if err := ptr.Validate(); err != nil {
 		return newError(err, *s, dv.Type())
 	}
-	if dv.Kind() != reflect.Ptr || dv.IsNil() {
+	if !isWriter(dv) && (dv.Kind() != reflect.Ptr || dv.IsNil()) {
 		return &ptrError{
 			state: *s,
 			err:   ErrNonPointer,
 			typ:   dv.Type(),
 		}
 	}
-	v, err := s.resolve(reflect.ValueOf(src))
+	s.scan = true
+	var v reflect.Value
+	var err error
+	if sv := reflect.ValueOf(src); isReader(sv) {
+		v, err = s.resolveReader(src.(io.Reader))
+	} else {
+		v, err = s.resolve(sv)
+	}
 	if err != nil {
 		return err
 	}
-	if isByteSlice(dv.Elem()) {
-		b, err := json.Marshal(v.Interface())
+	return s.setResolved(dv, v)
+}
+
+// setResolved assigns the resolved value v to dv, encoding v as JSON if dv is
+// a pointer to a byte slice which does not implement encoding.TextUnmarshaler
+// (e.g. net.IP).
+//
+// Raw JSON which was scanned from the source is decoded unless dv is a
+// pointer to a byte slice, in which case it is set to the scanned bytes. See
+// decodeRaw.
+//
+// If dv is an io.Writer, the JSON encoding of v is written to it instead.
+func (s *state) setResolved(dv reflect.Value, v reflect.Value) error {
+	if writesTo(dv, v) {
+		return s.writeResolved(dv.Interface().(io.Writer), v)
+	}
+	if v.IsValid() && v.Type() == typeRawJSON {
+		if dv.Elem().Kind() == reflect.Slice && isByteSlice(dv.Elem()) && !dv.Type().Implements(typeTextUnmarshaler) {
+			dv.Elem().SetBytes(v.Bytes())
+			return nil
+		}
+		if s.decodeRaw(dv, v.Bytes()) {
+			return nil
+		}
+		d, err := s.unmarshal(v)
+		if err != nil {
+			return err
+		}
+		v = d.Elem()
+	}
+	if isByteSlice(dv.Elem()) && !dv.Type().Implements(typeTextUnmarshaler) {
+		b, err := s.opts.codec().Marshal(v.Interface())
 		if err != nil {
 			return newError(err, *s, dv.Type())
 		}
@@ -54,3 +133,28 @@ func Resolve(src interface{}, ptr Pointer, dst interface{}) error {
 	}
 	return s.setValue(dv, v)
 }
+
+// decodeRaw decodes raw, which was scanned from the source, directly into the
+// value dv points to so that the result is as it would be had the Codec of s
+// decoded the document, e.g. integers beyond the precision of a float64 are
+// retained.
+//
+// ok is false if dv points to an interface, which is assigned generic JSON, or
+// raw can not be decoded into it; raw should then be decoded and converted as
+// any other value so that errors are reported as they otherwise would be.
+func (s *state) decodeRaw(dv reflect.Value, raw []byte) (ok bool) {
+	typ := dv.Type().Elem()
+	et := typ
+	for et.Kind() == reflect.Ptr {
+		et = et.Elem()
+	}
+	if et.Kind() == reflect.Interface {
+		return false
+	}
+	nv := reflect.New(typ)
+	if err := s.opts.codec().Unmarshal(raw, nv.Interface()); err != nil {
+		return false
+	}
+	dv.Elem().Set(nv.Elem())
+	return true
+}
@@ -0,0 +1,179 @@
+// Copyright 2022 Chance Dinkins
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+//
+// The License can be found in the LICENSE file.
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonpointer
+
+import (
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Errors is a collection of errors keyed by the Pointer which encountered
+// them.
+type Errors map[Pointer]error
+
+func (e Errors) Error() string {
+	ptrs := e.pointers()
+	b := strings.Builder{}
+	b.WriteString("jsonpointer: ")
+	b.WriteString(strconv.Itoa(len(ptrs)))
+	if len(ptrs) == 1 {
+		b.WriteString(" error: ")
+	} else {
+		b.WriteString(" errors: ")
+	}
+	for i, p := range ptrs {
+		if i > 0 {
+			b.WriteString("; ")
+		}
+		b.WriteString(e[p].Error())
+	}
+	return b.String()
+}
+
+// Unwrap returns each of the errors of e, ordered by Pointer.
+func (e Errors) Unwrap() []error {
+	ptrs := e.pointers()
+	errs := make([]error, len(ptrs))
+	for i, p := range ptrs {
+		errs[i] = e[p]
+	}
+	return errs
+}
+
+func (e Errors) pointers() []Pointer {
+	ptrs := make([]Pointer, 0, len(e))
+	for p := range e {
+		ptrs = append(ptrs, p)
+	}
+	sort.Slice(ptrs, func(i, j int) bool {
+		return ptrs[i].Compare(ptrs[j]) < 0
+	})
+	return ptrs
+}
+
+// ResolveAll performs resolution on src for each Pointer of dsts, assigning
+// the value to the corresponding destination. It is equivalent to calling
+// Resolve for each entry, except that src is traversed once: values at
+// shared prefixes are resolved a single time and raw JSON is decoded a single
+// time.
+//
+// This includes a Resolver along the path, which is invoked once for the
+// first pointer (in the order of Compare) to pass through it. The value it
+// resolves is then used for every other pointer which shares the prefix, so a
+// Resolver must resolve the same value for a token regardless of the tokens
+// which follow it.
+//
+// If resolution fails for any of the pointers, the remaining pointers are
+// still resolved and an Errors containing the error of each pointer which
+// failed is returned.
+func ResolveAll(src interface{}, dsts map[Pointer]interface{}, opts ...Option) error {
+	ptrs := make([]Pointer, 0, len(dsts))
+	for p := range dsts {
+		ptrs = append(ptrs, p)
+	}
+	sort.Slice(ptrs, func(i, j int) bool {
+		return ptrs[i].Compare(ptrs[j]) < 0
+	})
+	r := resolveAll{
+		s:       newState(Root, Resolving, newOptions(opts)),
+		cache:   map[Pointer]reflect.Value{Root: reflect.ValueOf(src)},
+		decoded: map[Pointer]reflect.Value{},
+	}
+	defer r.s.Release()
+	errs := Errors{}
+	for _, p := range ptrs {
+		if err := r.resolve(p, dsts[p]); err != nil {
+			errs[p] = err
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+type resolveAll struct {
+	s *state
+	// cache contains the resolved value of each Pointer which has been
+	// traversed.
+	cache map[Pointer]reflect.Value
+	// decoded contains the decoded value of cached raw JSON.
+	decoded map[Pointer]reflect.Value
+}
+
+func (r *resolveAll) resolve(ptr Pointer, dst interface{}) error {
+	s := r.s
+	s.ptr = ptr
+	s.current = ptr
+	dv := reflect.ValueOf(dst)
+	if err := ptr.Validate(); err != nil {
+		return newError(err, *s, dv.Type())
+	}
+	if !isWriter(dv) && (dv.Kind() != reflect.Ptr || dv.IsNil()) {
+		return &ptrError{
+			state: *s,
+			err:   ErrNonPointer,
+			typ:   dv.Type(),
+		}
+	}
+	// finding the nearest ancestor (or ptr itself) which has been resolved
+	base := ptr
+	v, ok := r.cache[base]
+	for !ok {
+		base, _ = base.Parent()
+		v, ok = r.cache[base]
+	}
+	s.current = ptr[len(base):]
+	var err error
+	for !s.current.IsRoot() {
+		if v, err = r.decode(base, v); err != nil {
+			return err
+		}
+		if v, err = s.resolveStep(v); err != nil {
+			return err
+		}
+		base = ptr[:len(ptr)-len(s.current)]
+		r.cache[base] = v
+	}
+	s.current = Root
+	return s.setResolved(dv, v)
+}
+
+// decode returns the decoded value of v if v is raw JSON, decoding it at most
+// once per Pointer.
+func (r *resolveAll) decode(ptr Pointer, v reflect.Value) (reflect.Value, error) {
+	if !v.IsValid() || v.Type().Implements(typeResolver) || v.Type().Implements(typeContextResolver) {
+		return v, nil
+	}
+	if v.Kind() == reflect.Ptr && !v.IsNil() && isByteSlice(v.Elem()) {
+		v = v.Elem()
+	}
+	if !isByteSlice(v) {
+		return v, nil
+	}
+	if d, ok := r.decoded[ptr]; ok {
+		return d, nil
+	}
+	d, err := r.s.unmarshal(v)
+	if err != nil {
+		return v, err
+	}
+	if !d.IsValid() {
+		return v, nil
+	}
+	r.decoded[ptr] = d
+	return d, nil
+}
@@ -0,0 +1,137 @@
+// Copyright 2022 Chance Dinkins
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+//
+// The License can be found in the LICENSE file.
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonpointer_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/chanced/jsonpointer"
+	"github.com/stretchr/testify/require"
+)
+
+type countingResolver struct {
+	calls *int
+	Inner map[string]string `json:"inner"`
+}
+
+func (c countingResolver) ResolveJSONPointer(ptr *jsonpointer.Pointer, op jsonpointer.Operation) (interface{}, error) {
+	*c.calls++
+	return nil, jsonpointer.YieldOperation
+}
+
+func TestResolveAll(t *testing.T) {
+	assert := require.New(t)
+	r := Root{
+		Nested: Nested{
+			Str:      "strval",
+			StrSlice: []string{"foo", "bar"},
+			EntryMap: map[string]*Entry{"foo": {Name: "bar", Value: 34.34}},
+			JSON:     json.RawMessage(`{"a":{"b":[1,2,3]}}`),
+		},
+	}
+	var (
+		str, name, second string
+		value             float64
+		raw               []byte
+		b, rawNested      interface{}
+		missing, badIdx   interface{}
+	)
+	err := jsonpointer.ResolveAll(r, map[jsonpointer.Pointer]interface{}{
+		"/nested/str":                &str,
+		"/nested/entrymap/foo/name":  &name,
+		"/nested/entrymap/foo/value": &value,
+		"/nested/strslice/1":         &second,
+		"/nested/json":               &raw,
+		"/nested/json/a/b/2":         &b,
+		"/nested/json/a":             &rawNested,
+	})
+	assert.NoError(err)
+	assert.Equal("strval", str)
+	assert.Equal("bar", name)
+	assert.Equal(34.34, value)
+	assert.Equal("bar", second)
+	assert.JSONEq(`{"a":{"b":[1,2,3]}}`, string(raw))
+	assert.Equal(float64(3), b)
+	assert.Equal(map[string]interface{}{"b": []interface{}{float64(1), float64(2), float64(3)}}, rawNested)
+
+	err = jsonpointer.ResolveAll(r, map[jsonpointer.Pointer]interface{}{
+		"/nested/str":           &str,
+		"/nested/missing":       &missing,
+		"/nested/strslice/5":    &badIdx,
+		"invalid":               &missing,
+		"/nested/entrymap/foo2": &missing,
+	})
+	assert.Error(err)
+	var errs jsonpointer.Errors
+	assert.True(errors.As(err, &errs))
+	assert.Len(errs, 4)
+	assert.ErrorIs(errs["/nested/missing"], jsonpointer.ErrNotFound)
+	assert.ErrorIs(errs["/nested/strslice/5"], jsonpointer.ErrOutOfRange)
+	assert.ErrorIs(errs["invalid"], jsonpointer.ErrMalformedStart)
+	assert.ErrorIs(errs["/nested/entrymap/foo2"], jsonpointer.ErrNotFound)
+	assert.ErrorIs(err, jsonpointer.ErrOutOfRange)
+
+	e, ok := jsonpointer.AsError(errs["/nested/missing"])
+	assert.True(ok)
+	assert.Equal(jsonpointer.Pointer("/nested/missing"), e.JSONPointer())
+	tok, _ := e.Token()
+	assert.Equal(jsonpointer.Token("missing"), tok)
+}
+
+func TestResolveAllJSON(t *testing.T) {
+	assert := require.New(t)
+	data := []byte(`{"foo":{"bar":[{"baz":1},{"baz":2}],"qux":"quux"}}`)
+	dsts := map[jsonpointer.Pointer]interface{}{}
+	vals := make([]interface{}, 4)
+	ptrs := []jsonpointer.Pointer{"/foo/bar/0/baz", "/foo/bar/1/baz", "/foo/qux", ""}
+	for i, p := range ptrs {
+		dsts[p] = &vals[i]
+	}
+	assert.NoError(jsonpointer.ResolveAll(data, dsts))
+	for i, p := range ptrs {
+		var expected interface{}
+		assert.NoError(jsonpointer.Resolve(data, p, &expected))
+		assert.Equal(expected, vals[i], "pointer %q", p)
+	}
+	assert.Equal(float64(1), vals[0])
+	assert.Equal(float64(2), vals[1])
+	assert.Equal("quux", vals[2])
+
+	err := jsonpointer.ResolveAll([]byte(`{"invalid`), map[jsonpointer.Pointer]interface{}{"/foo": &vals[0]})
+	assert.Error(err)
+}
+
+func TestResolveAllSharedPrefix(t *testing.T) {
+	assert := require.New(t)
+	calls := 0
+	src := map[string]countingResolver{
+		"x": {calls: &calls, Inner: map[string]string{"a": "A", "b": "B"}},
+	}
+	var a, b string
+	err := jsonpointer.ResolveAll(src, map[jsonpointer.Pointer]interface{}{
+		"/x/inner/a": &a,
+		"/x/inner/b": &b,
+	})
+	assert.NoError(err)
+	assert.Equal("A", a)
+	assert.Equal("B", b)
+	assert.Equal(1, calls)
+
+	calls = 0
+	assert.NoError(jsonpointer.Resolve(src, "/x/inner/a", &a))
+	assert.NoError(jsonpointer.Resolve(src, "/x/inner/b", &b))
+	assert.Equal(2, calls)
+}
@@ -243,6 +243,7 @@ func TestResolveJSON(t *testing.T) {
 	}{
 		{"/nested/str", `{"nested":{"str":"foo"}}`, "foo", nil},
 		{"/nested", `{"nested":{"str":"foo"}}`, []byte(`{"str":"foo"}`), nil},
+		{"/nested", `null`, "", jsonpointer.ErrUnreachable},
 	}
 
 	for i, test := range tests {
@@ -252,7 +253,7 @@ func TestResolveJSON(t *testing.T) {
 		v := rv.Interface()
 		err := jsonpointer.Resolve([]byte(test.json), test.ptr, &v)
 		if test.err != nil {
-			assert.Error(err)
+			assert.ErrorIs(err, test.err)
 		} else {
 			assert.NoError(err)
 			assert.Equal(test.val, v)
@@ -260,3 +261,29 @@ func TestResolveJSON(t *testing.T) {
 		fmt.Println("--- PASS")
 	}
 }
+
+func TestResolveErrorPointer(t *testing.T) {
+	assert := require.New(t)
+
+	tests := []struct {
+		ptr      jsonpointer.Pointer
+		expected jsonpointer.Pointer
+	}{
+		{"/missing", "/missing"},
+		{"/a/missing/x", "/missing/x"},
+		{"/a/b/missing", "/missing"},
+	}
+	src := map[string]interface{}{"a": map[string]interface{}{"b": map[string]interface{}{}}}
+
+	for i, test := range tests {
+		fmt.Printf("=== RUN TestResolveErrorPointer #%d, pointer %s\n", i, test.ptr)
+		var v interface{}
+		err := jsonpointer.Resolve(src, test.ptr, &v)
+		assert.ErrorIs(err, jsonpointer.ErrNotFound)
+		perr, ok := jsonpointer.AsError(err)
+		assert.True(ok)
+		// the failing token is reported once, followed by the remainder
+		assert.Equal(test.expected, perr.CurrentJSONPointer(), "test %d", i)
+		fmt.Println("--- PASS")
+	}
+}
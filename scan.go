@@ -0,0 +1,386 @@
+// Copyright 2022 Chance Dinkins
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+//
+// The License can be found in the LICENSE file.
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonpointer
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"math"
+	"reflect"
+	"unicode/utf8"
+)
+
+var errScan = errors.New("jsonpointer: invalid JSON")
+
+// rawJSON is a value of raw JSON which has been scanned from a larger
+// document. It is a subslice of the document.
+type rawJSON []byte
+
+var typeRawJSON = reflect.TypeOf(rawJSON{})
+
+// scanRaw resolves t from v, which is raw JSON, by scanning if s.scan is set
+// and raw JSON is decoded by encoding/json. See scanNext.
+func (s *state) scanRaw(v reflect.Value, t Token) (reflect.Value, bool) {
+	if !s.scan || s.opts.Codec != nil {
+		return reflect.Value{}, false
+	}
+	if v.Kind() == reflect.Interface {
+		v = v.Elem()
+	}
+	return s.scanNext(v.Bytes(), t)
+}
+
+// scanNext resolves t from data, which is raw JSON, by scanning rather than
+// decoding data. The value is returned as rawJSON, a nil interface{} if it is
+// null, or an invalid reflect.Value if data is an object without the member t.
+//
+// ok is false if the value can not be determined by scanning, such as when
+// data is not an object or array, the index is out of range, or data is
+// invalid. data should then be decoded so that the result, including any
+// error, is as it otherwise would be.
+func (s *state) scanNext(data []byte, t Token) (v reflect.Value, ok bool) {
+	sc := scanner{data: data}
+	sc.skipSpace()
+	if sc.eof() {
+		return reflect.Value{}, false
+	}
+	var raw []byte
+	var err error
+	switch sc.data[sc.pos] {
+	case '{':
+		raw, err = sc.member(t.String())
+	case '[':
+		if t == "-" {
+			return reflect.Value{}, false
+		}
+		i, ierr := s.index(t, math.MaxInt)
+		if ierr != nil {
+			return reflect.Value{}, false
+		}
+		raw, err = sc.element(i)
+		if err == nil && raw == nil {
+			// out of range
+			return reflect.Value{}, false
+		}
+	default:
+		return reflect.Value{}, false
+	}
+	if err != nil {
+		return reflect.Value{}, false
+	}
+	if sc.skipSpace(); !sc.eof() {
+		// trailing data
+		return reflect.Value{}, false
+	}
+	if raw == nil {
+		return reflect.Value{}, true
+	}
+	return rawValue(raw), true
+}
+
+// rawValue returns raw as rawJSON or, if it is null, a nil interface{} as
+// decoding it would.
+func rawValue(raw []byte) reflect.Value {
+	if bytes.Equal(raw, []byte("null")) {
+		return reflect.Zero(typeAny)
+	}
+	return reflect.ValueOf(rawJSON(raw))
+}
+
+// maxScanDepth is the maximum nesting of objects and arrays which is scanned,
+// as it is for encoding/json. Deeper documents are left to be decoded so that
+// the error is reported as it otherwise would be.
+const maxScanDepth = 10000
+
+// scanner reads raw JSON, skipping over values without decoding them.
+//
+// Skipped values are validated as encoding/json would validate them, so that
+// a scan fails wherever decoding the document would.
+type scanner struct {
+	data  []byte
+	pos   int
+	depth int
+}
+
+func (sc *scanner) eof() bool {
+	return sc.pos >= len(sc.data)
+}
+
+func (sc *scanner) skipSpace() {
+	for ; sc.pos < len(sc.data); sc.pos++ {
+		switch sc.data[sc.pos] {
+		case ' ', '\t', '\n', '\r':
+		default:
+			return
+		}
+	}
+}
+
+// consume skips whitespace and then c, returning errScan if the next byte is
+// not c.
+func (sc *scanner) consume(c byte) error {
+	sc.skipSpace()
+	if sc.eof() || sc.data[sc.pos] != c {
+		return errScan
+	}
+	sc.pos++
+	return nil
+}
+
+// member returns the value of the last member of the object at sc.pos named
+// key, as encoding/json would when decoding, or nil if there is no such
+// member.
+func (sc *scanner) member(key string) ([]byte, error) {
+	if err := sc.consume('{'); err != nil {
+		return nil, err
+	}
+	if err := sc.descend(); err != nil {
+		return nil, err
+	}
+	defer sc.ascend()
+	var res []byte
+	sc.skipSpace()
+	if !sc.eof() && sc.data[sc.pos] == '}' {
+		sc.pos++
+		return nil, nil
+	}
+	for {
+		sc.skipSpace()
+		k, err := sc.str()
+		if err != nil {
+			return nil, err
+		}
+		if err = sc.consume(':'); err != nil {
+			return nil, err
+		}
+		sc.skipSpace()
+		start := sc.pos
+		if err = sc.skipValue(); err != nil {
+			return nil, err
+		}
+		if keyEquals(k, key) {
+			res = sc.data[start:sc.pos]
+		}
+		sc.skipSpace()
+		if sc.eof() {
+			return nil, errScan
+		}
+		switch sc.data[sc.pos] {
+		case ',':
+			sc.pos++
+		case '}':
+			sc.pos++
+			return res, nil
+		default:
+			return nil, errScan
+		}
+	}
+}
+
+// element returns the element at index i of the array at sc.pos, or nil if
+// the array has i or fewer elements. The remaining elements are skipped so
+// that the array is validated in full.
+func (sc *scanner) element(i int) ([]byte, error) {
+	if err := sc.consume('['); err != nil {
+		return nil, err
+	}
+	if err := sc.descend(); err != nil {
+		return nil, err
+	}
+	defer sc.ascend()
+	var res []byte
+	sc.skipSpace()
+	if !sc.eof() && sc.data[sc.pos] == ']' {
+		sc.pos++
+		return nil, nil
+	}
+	for n := 0; ; n++ {
+		sc.skipSpace()
+		start := sc.pos
+		if err := sc.skipValue(); err != nil {
+			return nil, err
+		}
+		if n == i {
+			res = sc.data[start:sc.pos]
+		}
+		sc.skipSpace()
+		if sc.eof() {
+			return nil, errScan
+		}
+		switch sc.data[sc.pos] {
+		case ',':
+			sc.pos++
+		case ']':
+			sc.pos++
+			return res, nil
+		default:
+			return nil, errScan
+		}
+	}
+}
+
+// descend enters an object or array, returning errScan if it is nested
+// deeper than maxScanDepth.
+func (sc *scanner) descend() error {
+	if sc.depth++; sc.depth > maxScanDepth {
+		return errScan
+	}
+	return nil
+}
+
+func (sc *scanner) ascend() {
+	sc.depth--
+}
+
+// str returns the string at sc.pos, including its quotes.
+func (sc *scanner) str() ([]byte, error) {
+	if sc.eof() || sc.data[sc.pos] != '"' {
+		return nil, errScan
+	}
+	start := sc.pos
+	for sc.pos++; sc.pos < len(sc.data); sc.pos++ {
+		switch c := sc.data[sc.pos]; {
+		case c == '\\':
+			sc.pos++
+			if sc.eof() {
+				return nil, errScan
+			}
+			switch sc.data[sc.pos] {
+			case '"', '\\', '/', 'b', 'f', 'n', 'r', 't':
+			case 'u':
+				if sc.pos+4 >= len(sc.data) {
+					return nil, errScan
+				}
+				for _, h := range sc.data[sc.pos+1 : sc.pos+5] {
+					if !isHex(h) {
+						return nil, errScan
+					}
+				}
+				sc.pos += 4
+			default:
+				return nil, errScan
+			}
+		case c == '"':
+			sc.pos++
+			return sc.data[start:sc.pos], nil
+		case c < 0x20:
+			return nil, errScan
+		}
+	}
+	return nil, errScan
+}
+
+// skipValue advances sc past the value at sc.pos, returning errScan if it is
+// not valid JSON.
+func (sc *scanner) skipValue() error {
+	if sc.eof() {
+		return errScan
+	}
+	switch c := sc.data[sc.pos]; c {
+	case '"':
+		_, err := sc.str()
+		return err
+	case '{':
+		_, err := sc.member("")
+		return err
+	case '[':
+		_, err := sc.element(-1)
+		return err
+	case 't':
+		return sc.literal("true")
+	case 'f':
+		return sc.literal("false")
+	case 'n':
+		return sc.literal("null")
+	default:
+		if c == '-' || isDigit(c) {
+			return sc.number()
+		}
+		return errScan
+	}
+}
+
+// literal advances sc past lit, returning errScan if it is not at sc.pos.
+func (sc *scanner) literal(lit string) error {
+	if !bytes.HasPrefix(sc.data[sc.pos:], []byte(lit)) {
+		return errScan
+	}
+	sc.pos += len(lit)
+	return nil
+}
+
+// number advances sc past the number at sc.pos.
+func (sc *scanner) number() error {
+	if sc.data[sc.pos] == '-' {
+		sc.pos++
+	}
+	switch {
+	case sc.eof():
+		return errScan
+	case sc.data[sc.pos] == '0':
+		sc.pos++
+	case isDigit(sc.data[sc.pos]):
+		sc.digits()
+	default:
+		return errScan
+	}
+	if !sc.eof() && sc.data[sc.pos] == '.' {
+		sc.pos++
+		if sc.digits() == 0 {
+			return errScan
+		}
+	}
+	if !sc.eof() && (sc.data[sc.pos] == 'e' || sc.data[sc.pos] == 'E') {
+		sc.pos++
+		if !sc.eof() && (sc.data[sc.pos] == '+' || sc.data[sc.pos] == '-') {
+			sc.pos++
+		}
+		if sc.digits() == 0 {
+			return errScan
+		}
+	}
+	return nil
+}
+
+// digits advances sc past a run of digits, returning its length.
+func (sc *scanner) digits() int {
+	start := sc.pos
+	for !sc.eof() && isDigit(sc.data[sc.pos]) {
+		sc.pos++
+	}
+	return sc.pos - start
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+func isHex(c byte) bool {
+	return isDigit(c) || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F'
+}
+
+// keyEquals reports whether the quoted JSON string k is equal to key once
+// unescaped.
+func keyEquals(k []byte, key string) bool {
+	in := k[1 : len(k)-1]
+	if bytes.IndexByte(in, '\\') < 0 && utf8.Valid(in) {
+		return string(in) == key
+	}
+	var uk string
+	if err := json.Unmarshal(k, &uk); err != nil {
+		return false
+	}
+	return uk == key
+}
@@ -0,0 +1,216 @@
+// Copyright 2022 Chance Dinkins
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+//
+// The License can be found in the LICENSE file.
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonpointer_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/chanced/jsonpointer"
+	"github.com/stretchr/testify/require"
+)
+
+// decodeCodec is encoding/json as a custom Codec, which disables scanning of
+// raw JSON.
+type decodeCodec struct{}
+
+func (decodeCodec) Marshal(v interface{}) ([]byte, error)      { return json.Marshal(v) }
+func (decodeCodec) Unmarshal(data []byte, v interface{}) error { return json.Unmarshal(data, v) }
+
+const scanDoc = `{
+	"str": "value",
+	"esc\"aped": "escaped key",
+	"sl/ash": "slash",
+	"ti~lde": "tilde",
+	"unié": "unicode key",
+	"num": 1.5e3,
+	"bool": false,
+	"null": null,
+	"dup": 1,
+	"dup": 2,
+	"empty": {},
+	"arr": [ {"a": [1, 2, {"b": "c"}]}, "x\"]", null, [] ],
+	"obj": { "nested": { "deep": [true, "}{"] } }
+}`
+
+func TestScanResolve(t *testing.T) {
+	assert := require.New(t)
+	doc := []byte(scanDoc)
+
+	tests := []jsonpointer.Pointer{
+		"/str",
+		"/esc\"aped",
+		"/sl~1ash",
+		"/ti~0lde",
+		"/unié",
+		"/num",
+		"/bool",
+		"/null",
+		"/null/x",
+		"/dup",
+		"/empty",
+		"/empty/x",
+		"/missing",
+		"/arr",
+		"/arr/0/a/2/b",
+		"/arr/1",
+		"/arr/2",
+		"/arr/3",
+		"/arr/3/0",
+		"/arr/4",
+		"/arr/-",
+		"/arr/x",
+		"/arr/01",
+		"/obj/nested/deep/1",
+		"/str/x",
+		"/num/0",
+	}
+
+	for i, ptr := range tests {
+		fmt.Printf("=== RUN TestScanResolve #%d, pointer %s\n", i, ptr)
+		var expected interface{}
+		expectedErr := jsonpointer.Resolve(doc, ptr, &expected, jsonpointer.WithCodec(decodeCodec{}))
+		var v interface{}
+		err := jsonpointer.Resolve(doc, ptr, &v)
+		assert.Equal(expected, v, "test %d", i)
+		if expectedErr != nil {
+			assert.Error(err, "test %d", i)
+			assert.Equal(expectedErr.Error(), err.Error(), "test %d", i)
+		} else {
+			assert.NoError(err, "test %d", i)
+		}
+
+		var eb, b []byte
+		expectedErr = jsonpointer.Resolve(doc, ptr, &eb, jsonpointer.WithCodec(decodeCodec{}))
+		err = jsonpointer.Resolve(doc, ptr, &b)
+		assert.Equal(expectedErr == nil, err == nil, "test %d", i)
+		if err == nil {
+			assert.JSONEq(string(eb), string(b), "test %d", i)
+		}
+		fmt.Println("--- PASS")
+	}
+}
+
+func TestScanResolveRaw(t *testing.T) {
+	assert := require.New(t)
+	doc := []byte(scanDoc)
+
+	var raw json.RawMessage
+	err := jsonpointer.Resolve(doc, "/obj/nested", &raw)
+	assert.NoError(err)
+	// the original bytes, including whitespace, are resolved
+	assert.Equal(`{ "deep": [true, "}{"] }`, string(raw))
+	assert.True(bytes.Contains(doc, raw))
+
+	var s string
+	err = jsonpointer.Resolve(json.RawMessage(doc), "/arr/1", &s)
+	assert.NoError(err)
+	assert.Equal(`x"]`, s)
+
+	var e struct {
+		A []interface{} `json:"a"`
+	}
+	err = jsonpointer.Resolve(doc, "/arr/0", &e)
+	assert.NoError(err)
+	assert.Len(e.A, 3)
+
+	// scanned numbers are decoded directly into the destination rather than
+	// through a float64
+	var n int64
+	err = jsonpointer.Resolve([]byte(`{"n": 9007199254740993}`), "/n", &n)
+	assert.NoError(err)
+	assert.Equal(int64(9007199254740993), n)
+
+	// invalid JSON anywhere in the document is reported as it would be when
+	// decoding
+	invalid := []string{
+		`{"a": {"b" 1}}`,
+		`{"a": {"b": 1}, "c": tru}`,
+		`{"a": {"b": 1}, "c": [}`,
+		`{"a": {"b": 1}, "c": "\x"}`,
+		`{"a": {"b": 1}, "c": 01}`,
+		`{"a": {"b": 1}, "c": 1.}`,
+		`{"a": {"b": 1}} x`,
+		`[{"b": 1}, {"c": [`,
+		`[{"b": 1}, nul]`,
+	}
+	ptrs := map[byte]jsonpointer.Pointer{'{': "/a/b", '[': "/0/b"}
+	for n, doc := range invalid {
+		fmt.Printf("=== RUN TestScanResolveRaw invalid #%d\n", n)
+		ptr := ptrs[doc[0]]
+		var i, expected int
+		expectedErr := jsonpointer.Resolve([]byte(doc), ptr, &expected, jsonpointer.WithCodec(decodeCodec{}))
+		err := jsonpointer.Resolve([]byte(doc), ptr, &i)
+		assert.Error(err, "invalid %d", n)
+		assert.Equal(expectedErr.Error(), err.Error(), "invalid %d", n)
+		var se *json.SyntaxError
+		assert.ErrorAs(err, &se, "invalid %d", n)
+		fmt.Println("--- PASS")
+	}
+}
+
+func TestScanResolveDepth(t *testing.T) {
+	assert := require.New(t)
+	nested := func(depth int) []byte {
+		return []byte(`{"a": ` + strings.Repeat("[", depth) + strings.Repeat("]", depth) + `, "b": 1}`)
+	}
+
+	var i int
+	err := jsonpointer.Resolve(nested(5000), "/b", &i)
+	assert.NoError(err)
+	assert.Equal(1, i)
+
+	// documents nested beyond the limit of encoding/json fail as they would
+	// when decoding
+	doc := nested(20000)
+	var expected int
+	expectedErr := jsonpointer.Resolve(doc, "/b", &expected, jsonpointer.WithCodec(decodeCodec{}))
+	assert.Error(expectedErr)
+	err = jsonpointer.Resolve(doc, "/b", &i)
+	assert.Error(err)
+	assert.Equal(expectedErr.Error(), err.Error())
+}
+
+func BenchmarkScanResolve(b *testing.B) {
+	var sb strings.Builder
+	sb.WriteString(`{"items":[`)
+	for i := 0; i < 10000; i++ {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		fmt.Fprintf(&sb, `{"id":%d,"name":"item %d","tags":["a","b","c"]}`, i, i)
+	}
+	sb.WriteString(`],"target":{"value":"found"}}`)
+	doc := []byte(sb.String())
+
+	b.Run("scan", func(b *testing.B) {
+		var s string
+		for i := 0; i < b.N; i++ {
+			if err := jsonpointer.Resolve(doc, "/target/value", &s); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("decode", func(b *testing.B) {
+		var s string
+		for i := 0; i < b.N; i++ {
+			if err := jsonpointer.Resolve(doc, "/target/value", &s, jsonpointer.WithCodec(decodeCodec{})); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
@@ -0,0 +1,281 @@
+// Copyright 2022 Chance Dinkins
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+//
+// The License can be found in the LICENSE file.
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonpointer
+
+import (
+	"sort"
+	"strings"
+)
+
+// PointerSet is a set of Pointers, stored as a prefix trie keyed by Token.
+//
+// Pointers are ordered token-wise: a Pointer precedes each of its
+// descendants and sibling tokens are ordered by Token.Compare. Pointers added
+// to the set are assumed to be valid. The zero value is an empty set ready to
+// use. A PointerSet is not safe for concurrent use.
+type PointerSet struct {
+	root setNode
+	len  int
+}
+
+type setNode struct {
+	children map[Token]*setNode
+	member   bool
+}
+
+// NewPointerSet returns a PointerSet containing ptrs.
+func NewPointerSet(ptrs ...Pointer) *PointerSet {
+	s := &PointerSet{}
+	for _, p := range ptrs {
+		s.Add(p)
+	}
+	return s
+}
+
+// Len returns the number of Pointers in s.
+func (s *PointerSet) Len() int {
+	return s.len
+}
+
+// Add adds p to s, returning true if p was not already present.
+func (s *PointerSet) Add(p Pointer) bool {
+	n := &s.root
+	p.Range(func(_ int, t Token) bool {
+		c, ok := n.children[t]
+		if !ok {
+			if n.children == nil {
+				n.children = map[Token]*setNode{}
+			}
+			c = &setNode{}
+			n.children[t] = c
+		}
+		n = c
+		return true
+	})
+	if n.member {
+		return false
+	}
+	n.member = true
+	s.len++
+	return true
+}
+
+// Remove removes p from s, returning true if p was present.
+func (s *PointerSet) Remove(p Pointer) bool {
+	if !s.root.remove(p.tokens()) {
+		return false
+	}
+	s.len--
+	return true
+}
+
+func (n *setNode) remove(tokens []Token) bool {
+	if len(tokens) == 0 {
+		if !n.member {
+			return false
+		}
+		n.member = false
+		return true
+	}
+	c, ok := n.children[tokens[0]]
+	if !ok || !c.remove(tokens[1:]) {
+		return false
+	}
+	if !c.member && len(c.children) == 0 {
+		delete(n.children, tokens[0])
+	}
+	return true
+}
+
+// Has returns true if p is in s.
+func (s *PointerSet) Has(p Pointer) bool {
+	n := s.find(p)
+	return n != nil && n.member
+}
+
+// Covers returns true if p or any of its ancestors is in s.
+func (s *PointerSet) Covers(p Pointer) bool {
+	n := &s.root
+	covered := n.member
+	p.Range(func(_ int, t Token) bool {
+		if covered {
+			return false
+		}
+		if n = n.children[t]; n == nil {
+			return false
+		}
+		covered = n.member
+		return true
+	})
+	return covered
+}
+
+// Under returns, in order, each of the Pointers of s which are either equal
+// to or descendants of prefix.
+func (s *PointerSet) Under(prefix Pointer) []Pointer {
+	n := s.find(prefix)
+	if n == nil {
+		return nil
+	}
+	var ptrs []Pointer
+	n.walk(prefix, func(p Pointer) bool {
+		ptrs = append(ptrs, p)
+		return true
+	})
+	return ptrs
+}
+
+// Pointers returns each of the Pointers of s, in order.
+func (s *PointerSet) Pointers() []Pointer {
+	ptrs := make([]Pointer, 0, s.len)
+	s.Range(func(p Pointer) bool {
+		ptrs = append(ptrs, p)
+		return true
+	})
+	return ptrs
+}
+
+// Range calls fn for each of the Pointers of s, in order. If fn returns false,
+// iteration stops.
+func (s *PointerSet) Range(fn func(p Pointer) bool) {
+	s.root.walk(Root, fn)
+}
+
+// MinimalCover returns a new PointerSet containing the Pointers of s which do
+// not have an ancestor in s. The result covers the same Pointers as s.
+func (s *PointerSet) MinimalCover() *PointerSet {
+	res := &PointerSet{}
+	s.root.walkCover(Root, func(p Pointer) {
+		res.Add(p)
+	})
+	return res
+}
+
+// Union returns a new PointerSet containing the Pointers which are in either s
+// or o.
+func (s *PointerSet) Union(o *PointerSet) *PointerSet {
+	res := &PointerSet{}
+	for _, set := range []*PointerSet{s, o} {
+		set.Range(func(p Pointer) bool {
+			res.Add(p)
+			return true
+		})
+	}
+	return res
+}
+
+// Intersect returns a new PointerSet containing the Pointers which are in both
+// s and o.
+//
+// Note: membership is exact. A Pointer in s which is only covered by an
+// ancestor in o is not included.
+func (s *PointerSet) Intersect(o *PointerSet) *PointerSet {
+	res := &PointerSet{}
+	s.Range(func(p Pointer) bool {
+		if o.Has(p) {
+			res.Add(p)
+		}
+		return true
+	})
+	return res
+}
+
+func (s *PointerSet) find(p Pointer) *setNode {
+	n := &s.root
+	p.Range(func(_ int, t Token) bool {
+		n = n.children[t]
+		return n != nil
+	})
+	return n
+}
+
+func (n *setNode) walk(p Pointer, fn func(Pointer) bool) bool {
+	if n.member && !fn(p) {
+		return false
+	}
+	for _, t := range n.sortedTokens() {
+		if !n.children[t].walk(p.Append(t), fn) {
+			return false
+		}
+	}
+	return true
+}
+
+func (n *setNode) walkCover(p Pointer, fn func(Pointer)) {
+	if n.member {
+		fn(p)
+		return
+	}
+	for _, t := range n.sortedTokens() {
+		n.children[t].walkCover(p.Append(t), fn)
+	}
+}
+
+func (n *setNode) sortedTokens() []Token {
+	tokens := make([]Token, 0, len(n.children))
+	for t := range n.children {
+		tokens = append(tokens, t)
+	}
+	sort.Slice(tokens, func(i, j int) bool {
+		return tokens[i].Compare(tokens[j]) < 0
+	})
+	return tokens
+}
+
+// Compare returns an integer comparing t and u. The result will be 0 if
+// t == u, -1 if t < u, and +1 if t > u.
+//
+// Array indexes, as defined by RFC 6901, are ordered numerically and precede
+// all other tokens. All other tokens are ordered by their decoded values.
+func (t Token) Compare(u Token) int {
+	ti, tok := t.canonicalIndex()
+	ui, uok := u.canonicalIndex()
+	switch {
+	case tok && uok:
+		switch {
+		case ti < ui:
+			return -1
+		case ti > ui:
+			return 1
+		default:
+			return 0
+		}
+	case tok:
+		return -1
+	case uok:
+		return 1
+	default:
+		return strings.Compare(t.String(), u.String())
+	}
+}
+
+// Compare returns an integer comparing p and q token-wise. The result will be
+// 0 if p == q, -1 if p < q, and +1 if p > q. A Pointer precedes each of its
+// descendants. See Token.Compare.
+func (p Pointer) Compare(q Pointer) int {
+	pt, qt := p.tokens(), q.tokens()
+	for i := 0; i < len(pt) && i < len(qt); i++ {
+		if c := pt[i].Compare(qt[i]); c != 0 {
+			return c
+		}
+	}
+	switch {
+	case len(pt) < len(qt):
+		return -1
+	case len(pt) > len(qt):
+		return 1
+	default:
+		return 0
+	}
+}
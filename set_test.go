@@ -0,0 +1,125 @@
+// Copyright 2022 Chance Dinkins
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+//
+// The License can be found in the LICENSE file.
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonpointer_test
+
+import (
+	"testing"
+
+	"github.com/chanced/jsonpointer"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPointerSet(t *testing.T) {
+	assert := require.New(t)
+	s := jsonpointer.NewPointerSet("/spec/b", "/spec/a", "/status", "/spec/a/x", "/items/10", "/items/2")
+	assert.Equal(6, s.Len())
+	assert.False(s.Add("/status"))
+	assert.True(s.Add("/items/a"))
+	assert.Equal(7, s.Len())
+
+	assert.Equal([]jsonpointer.Pointer{
+		"/items/2",
+		"/items/10",
+		"/items/a",
+		"/spec/a",
+		"/spec/a/x",
+		"/spec/b",
+		"/status",
+	}, s.Pointers())
+
+	assert.True(s.Has("/spec/a"))
+	assert.False(s.Has("/spec"))
+	assert.False(s.Has("/spec/a/x/y"))
+
+	assert.True(s.Covers("/spec/a"))
+	assert.True(s.Covers("/spec/a/y/z"))
+	assert.True(s.Covers("/status/phase"))
+	assert.False(s.Covers("/spec"))
+	assert.False(s.Covers("/spec/c"))
+	assert.False(s.Covers("/statusx"))
+
+	assert.Equal([]jsonpointer.Pointer{"/spec/a", "/spec/a/x", "/spec/b"}, s.Under("/spec"))
+	assert.Equal([]jsonpointer.Pointer{"/spec/a", "/spec/a/x"}, s.Under("/spec/a"))
+	assert.Nil(s.Under("/spec/c"))
+	assert.Equal(s.Pointers(), s.Under(jsonpointer.Root))
+
+	assert.Equal([]jsonpointer.Pointer{
+		"/items/2",
+		"/items/10",
+		"/items/a",
+		"/spec/a",
+		"/spec/b",
+		"/status",
+	}, s.MinimalCover().Pointers())
+
+	assert.True(s.Remove("/spec/a/x"))
+	assert.False(s.Remove("/spec/a/x"))
+	assert.False(s.Remove("/spec"))
+	assert.Equal(6, s.Len())
+	assert.Equal([]jsonpointer.Pointer{"/spec/a", "/spec/b"}, s.Under("/spec"))
+
+	var visited []jsonpointer.Pointer
+	s.Range(func(p jsonpointer.Pointer) bool {
+		visited = append(visited, p)
+		return len(visited) < 2
+	})
+	assert.Equal([]jsonpointer.Pointer{"/items/2", "/items/10"}, visited)
+}
+
+func TestPointerSetRoot(t *testing.T) {
+	assert := require.New(t)
+	var s jsonpointer.PointerSet
+	assert.False(s.Covers("/foo"))
+	assert.True(s.Add(jsonpointer.Root))
+	assert.True(s.Add("/foo"))
+	assert.True(s.Covers("/foo/bar"))
+	assert.True(s.Covers(jsonpointer.Root))
+	assert.Equal([]jsonpointer.Pointer{""}, s.MinimalCover().Pointers())
+	assert.Equal([]jsonpointer.Pointer{"", "/foo"}, s.Pointers())
+}
+
+func TestPointerSetUnionIntersect(t *testing.T) {
+	assert := require.New(t)
+	a := jsonpointer.NewPointerSet("/a", "/b", "/c/d")
+	b := jsonpointer.NewPointerSet("/b", "/c", "/c/d", "/e")
+
+	assert.Equal([]jsonpointer.Pointer{"/a", "/b", "/c", "/c/d", "/e"}, a.Union(b).Pointers())
+	assert.Equal([]jsonpointer.Pointer{"/b", "/c/d"}, a.Intersect(b).Pointers())
+	assert.Equal(2, a.Intersect(b).Len())
+	assert.Equal(3, a.Len())
+}
+
+func TestPointerCompare(t *testing.T) {
+	assert := require.New(t)
+	tests := []struct {
+		p        jsonpointer.Pointer
+		q        jsonpointer.Pointer
+		expected int
+	}{
+		{"", "", 0},
+		{"", "/a", -1},
+		{"/a", "/a/b", -1},
+		{"/a/b", "/a", 1},
+		{"/2", "/10", -1},
+		{"/10", "/a", -1},
+		{"/01", "/1", 1},
+		{"/a~1b", "/a~0b", -1},
+		{"/foo/bar", "/foo/bar", 0},
+		{"/foo/bar", "/foobar", -1},
+	}
+	for _, test := range tests {
+		assert.Equal(test.expected, test.p.Compare(test.q), "%q.Compare(%q)", test.p, test.q)
+		assert.Equal(-test.expected, test.q.Compare(test.p), "%q.Compare(%q)", test.q, test.p)
+	}
+}
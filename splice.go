@@ -0,0 +1,450 @@
+// Copyright 2022 Chance Dinkins
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+//
+// The License can be found in the LICENSE file.
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonpointer
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+)
+
+// rawContainer is an object or array located within raw JSON.
+type rawContainer struct {
+	object bool
+	// open and close are the indexes of the delimiters.
+	open    int
+	close   int
+	entries []rawEntry
+}
+
+// rawEntry is a member of an object or an element of an array located within
+// raw JSON. The key of a member is data[start:keyEnd]; for an element, start
+// is equal to valStart.
+type rawEntry struct {
+	start    int
+	keyEnd   int
+	valStart int
+	valEnd   int
+}
+
+// container reads the object or array at sc.pos.
+func (sc *scanner) container() (rawContainer, error) {
+	sc.skipSpace()
+	if sc.eof() {
+		return rawContainer{}, errScan
+	}
+	c := rawContainer{open: sc.pos}
+	var end byte
+	switch sc.data[sc.pos] {
+	case '{':
+		c.object = true
+		end = '}'
+	case '[':
+		end = ']'
+	default:
+		return c, errScan
+	}
+	sc.pos++
+	sc.skipSpace()
+	if !sc.eof() && sc.data[sc.pos] == end {
+		c.close = sc.pos
+		sc.pos++
+		return c, nil
+	}
+	for {
+		sc.skipSpace()
+		e := rawEntry{start: sc.pos}
+		if c.object {
+			if _, err := sc.str(); err != nil {
+				return c, err
+			}
+			e.keyEnd = sc.pos
+			if err := sc.consume(':'); err != nil {
+				return c, err
+			}
+			sc.skipSpace()
+		}
+		e.valStart = sc.pos
+		if err := sc.skipValue(); err != nil {
+			return c, err
+		}
+		e.valEnd = sc.pos
+		c.entries = append(c.entries, e)
+		sc.skipSpace()
+		if sc.eof() {
+			return c, errScan
+		}
+		switch sc.data[sc.pos] {
+		case ',':
+			sc.pos++
+		case end:
+			c.close = sc.pos
+			sc.pos++
+			return c, nil
+		default:
+			return c, errScan
+		}
+	}
+}
+
+// isRawDocument reports whether v is a non-empty byte slice of raw JSON which
+// may be edited by splicing.
+func isRawDocument(v reflect.Value) bool {
+	return v.Kind() == reflect.Slice && isByteSlice(v) && v.Len() > 0
+}
+
+// splicer edits raw JSON by replacing only the bytes of the values which are
+// affected, leaving the order of members, whitespace, and the literals of all
+// other values as they were.
+type splicer struct {
+	s    *state
+	data []byte
+	// colon is the separator between the key and value of the first member
+	// located, including any whitespace.
+	colon []byte
+	// located are the containers along the path.
+	located []rawContainer
+}
+
+// spliceAssign assigns val to the raw JSON of dst at ptr by splicing. It
+// returns false if the assignment can not be made by splicing, in which case
+// it should be made by decoding and encoding the JSON. If val is itself raw
+// JSON, it replaces an existing value exactly as it is.
+//
+// The JSON is not spliced if dst implements Assigner or ContextAssigner, which
+// is called instead, or if the JSON is invalid, so that the error is reported
+// as it otherwise would be.
+func (s *state) spliceAssign(dst reflect.Value, ptr Pointer, val reflect.Value) bool {
+	if _, ok := s.asAssigner(dst); ok || !json.Valid(dst.Elem().Bytes()) {
+		return false
+	}
+	sp := splicer{s: s, data: dst.Elem().Bytes()}
+	c, rest, ok := sp.locate(ptr)
+	if !ok {
+		return false
+	}
+	next, t, _ := rest.Next()
+	if !next.IsRoot() && s.opts.NoCreate {
+		return false
+	}
+	b, ok := sp.encode(val)
+	if !ok {
+		return false
+	}
+	if !next.IsRoot() {
+		if b, ok = sp.tree(next, b); !ok {
+			return false
+		}
+	}
+	i, ok := sp.find(c, t)
+	if !ok {
+		return false
+	}
+	switch {
+	case i >= 0 && isByteSlice(val):
+		// raw JSON replaces the value byte for byte, including its
+		// whitespace
+		sp.splice(c.entries[i].valStart, c.entries[i].valEnd, b)
+	case i >= 0:
+		sp.replace(c.entries[i], b)
+	default:
+		sp.insert(c, t, b)
+	}
+	dst.Elem().SetBytes(sp.data)
+	return true
+}
+
+// spliceDelete deletes the value at ptr from the raw JSON of dst by
+// splicing. It returns false if the deletion can not be made by splicing, in
+// which case it should be made by decoding and encoding the JSON.
+//
+// As with spliceAssign, the JSON is not spliced if dst implements Deleter or
+// ContextDeleter, or if the JSON is invalid.
+func (s *state) spliceDelete(dst reflect.Value, ptr Pointer) bool {
+	if _, ok := s.asDeleter(dst); ok || !json.Valid(dst.Elem().Bytes()) {
+		return false
+	}
+	sp := splicer{s: s, data: dst.Elem().Bytes()}
+	c, rest, ok := sp.locate(ptr)
+	if !ok || !c.object && rest.Len() > 1 {
+		return false
+	}
+	if rest.Len() > 1 {
+		// an object along the path does not have the member; there is
+		// nothing to delete
+		return true
+	}
+	t, _ := rest.NextToken()
+	i, ok := sp.find(c, t)
+	if !ok || !c.object && i < 0 || c.object && sp.count(c, t) > 1 {
+		return false
+	}
+	if i < 0 {
+		return true
+	}
+	var from, to int
+	switch {
+	case len(c.entries) == 1:
+		from, to = c.open+1, c.close
+	case i > 0:
+		// the separator preceding the entry is removed along with it
+		from, to = c.entries[i-1].valEnd, c.entries[i].valEnd
+	default:
+		from, to = c.entries[0].start, c.entries[1].start
+	}
+	sp.splice(from, to, nil)
+	dst.Elem().SetBytes(sp.data)
+	return true
+}
+
+// locate returns the container of the value referenced by the final token of
+// ptr along with the remainder of ptr from the token of that value. If a
+// value along the path is absent, the container which would hold it is
+// returned instead, with the remainder of ptr from its token.
+func (sp *splicer) locate(ptr Pointer) (rawContainer, Pointer, bool) {
+	pos := 0
+	for {
+		sc := scanner{data: sp.data, pos: pos}
+		c, err := sc.container()
+		if err != nil {
+			return c, ptr, false
+		}
+		sp.located = append(sp.located, c)
+		if c.object && sp.colon == nil && len(c.entries) > 0 {
+			e := c.entries[0]
+			sp.colon = sp.data[e.keyEnd:e.valStart]
+		}
+		next, t, _ := ptr.Next()
+		if next.IsRoot() {
+			return c, ptr, true
+		}
+		i, ok := sp.find(c, t)
+		if !ok {
+			return c, ptr, false
+		}
+		if i < 0 {
+			return c, ptr, true
+		}
+		pos = c.entries[i].valStart
+		ptr = next
+	}
+}
+
+// find returns the index of the entry of c referenced by t, or -1 if there is
+// no such entry but one may be added for t. It returns false if t is not a
+// valid index of an array.
+func (sp *splicer) find(c rawContainer, t Token) (int, bool) {
+	if !c.object {
+		if t == "-" {
+			return -1, true
+		}
+		i, err := sp.s.index(t, len(c.entries))
+		if err != nil {
+			return -1, false
+		}
+		if i == len(c.entries) {
+			return -1, true
+		}
+		return i, true
+	}
+	key := t.String()
+	// the last of repeated members is used, as it is when decoding
+	for i := len(c.entries) - 1; i >= 0; i-- {
+		e := c.entries[i]
+		if keyEquals(sp.data[e.start:e.keyEnd], key) {
+			return i, true
+		}
+	}
+	return -1, true
+}
+
+// count returns the number of members of the object c named by t.
+func (sp *splicer) count(c rawContainer, t Token) int {
+	key := t.String()
+	n := 0
+	for _, e := range c.entries {
+		if keyEquals(sp.data[e.start:e.keyEnd], key) {
+			n++
+		}
+	}
+	return n
+}
+
+// encode returns the JSON encoding of val. Byte slices are considered to
+// already be JSON.
+func (sp *splicer) encode(val reflect.Value) ([]byte, bool) {
+	if val.Kind() == reflect.Interface {
+		val = val.Elem()
+	}
+	if !val.IsValid() {
+		return nil, false
+	}
+	if isByteSlice(val) {
+		b := val.Bytes()
+		return b, json.Valid(b)
+	}
+	b, err := sp.s.opts.codec().Marshal(val.Interface())
+	return b, err == nil
+}
+
+// tree returns the JSON encoding of the containers which are created when
+// assigning b at ptr to an absent value, as Assign would create them.
+func (sp *splicer) tree(ptr Pointer, b []byte) ([]byte, bool) {
+	var tokens []Token
+	ptr.Range(func(_ int, t Token) bool {
+		tokens = append(tokens, t)
+		return true
+	})
+	var v interface{} = json.RawMessage(b)
+	for i := len(tokens) - 1; i >= 0; i-- {
+		if _, err := sp.s.index(tokens[i], 0); err == nil {
+			v = []interface{}{v}
+		} else {
+			v = map[string]interface{}{tokens[i].String(): v}
+		}
+	}
+	b, err := json.Marshal(v)
+	return b, err == nil
+}
+
+// replace replaces the value of e with b.
+func (sp *splicer) replace(e rawEntry, b []byte) {
+	if ws := sp.space(e.start); bytes.IndexByte(ws, '\n') >= 0 {
+		b = sp.indent(b, sp.lineIndent(e.start))
+	}
+	sp.splice(e.valStart, e.valEnd, b)
+}
+
+// insert adds b to c as the member named t or as the last element. The new
+// entry follows the layout of the last entry of c or, if c is empty, that of
+// the document.
+func (sp *splicer) insert(c rawContainer, t Token, b []byte) {
+	var key []byte
+	if c.object {
+		key, _ = json.Marshal(t.String())
+	}
+	var buf bytes.Buffer
+	if len(c.entries) > 0 {
+		last := c.entries[len(c.entries)-1]
+		ws := sp.space(last.start)
+		buf.WriteByte(',')
+		buf.Write(ws)
+		if c.object {
+			buf.Write(key)
+			buf.Write(sp.data[last.keyEnd:last.valStart])
+		}
+		if bytes.IndexByte(ws, '\n') >= 0 {
+			b = sp.indent(b, sp.lineIndent(last.start))
+		}
+		buf.Write(b)
+		sp.splice(last.valEnd, last.valEnd, buf.Bytes())
+		return
+	}
+	multiline := bytes.IndexByte(sp.data, '\n') >= 0
+	outer := sp.lineIndent(c.open)
+	inner := append(append([]byte{}, outer...), sp.unit()...)
+	if multiline {
+		buf.WriteByte('\n')
+		buf.Write(inner)
+	}
+	if c.object {
+		buf.Write(key)
+		switch {
+		case sp.colon != nil:
+			buf.Write(sp.colon)
+		case multiline:
+			buf.WriteString(": ")
+		default:
+			buf.WriteByte(':')
+		}
+	}
+	if multiline {
+		b = sp.indent(b, inner)
+	}
+	buf.Write(b)
+	if multiline {
+		buf.WriteByte('\n')
+		buf.Write(outer)
+	}
+	sp.splice(c.open+1, c.close, buf.Bytes())
+}
+
+// splice replaces sp.data[from:to] with b. The underlying array of sp.data is
+// never modified, as it is shared with the source.
+func (sp *splicer) splice(from, to int, b []byte) {
+	data := make([]byte, 0, len(sp.data)-(to-from)+len(b))
+	data = append(data, sp.data[:from]...)
+	data = append(data, b...)
+	sp.data = append(data, sp.data[to:]...)
+}
+
+// space returns the whitespace which precedes pos.
+func (sp *splicer) space(pos int) []byte {
+	i := pos
+	for i > 0 && isSpace(sp.data[i-1]) {
+		i--
+	}
+	return sp.data[i:pos]
+}
+
+// lineIndent returns the indentation of the line which contains pos.
+func (sp *splicer) lineIndent(pos int) []byte {
+	start := bytes.LastIndexByte(sp.data[:pos], '\n') + 1
+	end := start
+	for end < pos && (sp.data[end] == ' ' || sp.data[end] == '\t') {
+		end++
+	}
+	return sp.data[start:end]
+}
+
+// unit returns the indentation of each level of the document, inferred from
+// the first container along the path whose entries are on their own lines.
+func (sp *splicer) unit() []byte {
+	for _, c := range sp.located {
+		if len(c.entries) == 0 || bytes.IndexByte(sp.space(c.entries[0].start), '\n') < 0 {
+			continue
+		}
+		outer, inner := sp.lineIndent(c.open), sp.lineIndent(c.entries[0].start)
+		if len(inner) > len(outer) && bytes.HasPrefix(inner, outer) {
+			return inner[len(outer):]
+		}
+	}
+	for _, line := range bytes.Split(sp.data, []byte("\n"))[1:] {
+		n := 0
+		for n < len(line) && (line[n] == ' ' || line[n] == '\t') {
+			n++
+		}
+		if n > 0 && n < len(line) {
+			return line[:n]
+		}
+	}
+	return []byte("  ")
+}
+
+// indent formats the JSON b so that each line following the first begins
+// with prefix and the indentation of its level.
+func (sp *splicer) indent(b []byte, prefix []byte) []byte {
+	var compact, buf bytes.Buffer
+	if err := json.Compact(&compact, b); err != nil {
+		return b
+	}
+	if err := json.Indent(&buf, compact.Bytes(), string(prefix), string(sp.unit())); err != nil {
+		return b
+	}
+	return buf.Bytes()
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
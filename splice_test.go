@@ -0,0 +1,364 @@
+// Copyright 2022 Chance Dinkins
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+//
+// The License can be found in the LICENSE file.
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonpointer_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/chanced/jsonpointer"
+	"github.com/stretchr/testify/require"
+)
+
+const spliceDoc = `{
+  "name": "svc",
+  "version": 1.0,
+  "path": "café",
+  "plugins": {},
+  "server": {
+    "port": 8080,
+    "hosts": [
+      "a",
+      "b"
+    ]
+  },
+  "tags": [1, 2]
+}`
+
+// hookedDoc is raw JSON which handles its own assignments and deletions by
+// recording them in place of the document.
+type hookedDoc []byte
+
+func (d *hookedDoc) AssignByJSONPointer(ptr *jsonpointer.Pointer, v interface{}) error {
+	*d = hookedDoc(fmt.Sprintf("assigned %v at %s", v, *ptr))
+	return nil
+}
+
+func (d *hookedDoc) DeleteByJSONPointer(ptr *jsonpointer.Pointer) error {
+	*d = hookedDoc(fmt.Sprintf("deleted %s", *ptr))
+	return nil
+}
+
+func TestPreserveFormatAssign(t *testing.T) {
+	assert := require.New(t)
+
+	tests := []struct {
+		ptr      jsonpointer.Pointer
+		value    interface{}
+		expected string
+	}{
+		{"/name", "api", `{
+  "name": "api",
+  "version": 1.0,
+  "path": "café",
+  "plugins": {},
+  "server": {
+    "port": 8080,
+    "hosts": [
+      "a",
+      "b"
+    ]
+  },
+  "tags": [1, 2]
+}`},
+		{"/server/timeout", "5s", `{
+  "name": "svc",
+  "version": 1.0,
+  "path": "café",
+  "plugins": {},
+  "server": {
+    "port": 8080,
+    "hosts": [
+      "a",
+      "b"
+    ],
+    "timeout": "5s"
+  },
+  "tags": [1, 2]
+}`},
+		{"/server/hosts/-", "c", `{
+  "name": "svc",
+  "version": 1.0,
+  "path": "café",
+  "plugins": {},
+  "server": {
+    "port": 8080,
+    "hosts": [
+      "a",
+      "b",
+      "c"
+    ]
+  },
+  "tags": [1, 2]
+}`},
+		{"/tags/2", 3, `{
+  "name": "svc",
+  "version": 1.0,
+  "path": "café",
+  "plugins": {},
+  "server": {
+    "port": 8080,
+    "hosts": [
+      "a",
+      "b"
+    ]
+  },
+  "tags": [1, 2, 3]
+}`},
+		{"/plugins/auth", map[string]interface{}{"enabled": true}, `{
+  "name": "svc",
+  "version": 1.0,
+  "path": "café",
+  "plugins": {
+    "auth": {
+      "enabled": true
+    }
+  },
+  "server": {
+    "port": 8080,
+    "hosts": [
+      "a",
+      "b"
+    ]
+  },
+  "tags": [1, 2]
+}`},
+		{"/limits/cpu/0", "1", `{
+  "name": "svc",
+  "version": 1.0,
+  "path": "café",
+  "plugins": {},
+  "server": {
+    "port": 8080,
+    "hosts": [
+      "a",
+      "b"
+    ]
+  },
+  "tags": [1, 2],
+  "limits": {
+    "cpu": [
+      "1"
+    ]
+  }
+}`},
+		{"/server", map[string]interface{}{"port": 9090}, `{
+  "name": "svc",
+  "version": 1.0,
+  "path": "café",
+  "plugins": {},
+  "server": {
+    "port": 9090
+  },
+  "tags": [1, 2]
+}`},
+	}
+
+	for i, test := range tests {
+		fmt.Printf("=== RUN TestPreserveFormatAssign #%d, pointer %s\n", i, test.ptr)
+		doc := []byte(spliceDoc)
+		err := jsonpointer.Assign(&doc, test.ptr, test.value, jsonpointer.WithPreserveFormat(true))
+		assert.NoError(err, "test %d", i)
+		assert.Equal(test.expected, string(doc), "test %d", i)
+
+		// the result is equivalent to that of decoding and encoding
+		expected := []byte(spliceDoc)
+		err = jsonpointer.Assign(&expected, test.ptr, test.value)
+		assert.NoError(err, "test %d", i)
+		assert.JSONEq(string(expected), string(doc), "test %d", i)
+		fmt.Println("--- PASS")
+	}
+}
+
+func TestPreserveFormatDelete(t *testing.T) {
+	assert := require.New(t)
+
+	tests := []struct {
+		ptr      jsonpointer.Pointer
+		expected string
+	}{
+		{"/name", `{
+  "version": 1.0,
+  "path": "café",
+  "plugins": {},
+  "server": {
+    "port": 8080,
+    "hosts": [
+      "a",
+      "b"
+    ]
+  },
+  "tags": [1, 2]
+}`},
+		{"/server/port", `{
+  "name": "svc",
+  "version": 1.0,
+  "path": "café",
+  "plugins": {},
+  "server": {
+    "hosts": [
+      "a",
+      "b"
+    ]
+  },
+  "tags": [1, 2]
+}`},
+		{"/server/hosts/1", `{
+  "name": "svc",
+  "version": 1.0,
+  "path": "café",
+  "plugins": {},
+  "server": {
+    "port": 8080,
+    "hosts": [
+      "a"
+    ]
+  },
+  "tags": [1, 2]
+}`},
+		{"/tags", `{
+  "name": "svc",
+  "version": 1.0,
+  "path": "café",
+  "plugins": {},
+  "server": {
+    "port": 8080,
+    "hosts": [
+      "a",
+      "b"
+    ]
+  }
+}`},
+		{"/missing/key", spliceDoc},
+		{"/plugins/missing", spliceDoc},
+	}
+
+	for i, test := range tests {
+		fmt.Printf("=== RUN TestPreserveFormatDelete #%d, pointer %s\n", i, test.ptr)
+		doc := []byte(spliceDoc)
+		err := jsonpointer.Delete(&doc, test.ptr, jsonpointer.WithPreserveFormat(true))
+		assert.NoError(err, "test %d", i)
+		assert.Equal(test.expected, string(doc), "test %d", i)
+
+		expected := []byte(spliceDoc)
+		err = jsonpointer.Delete(&expected, test.ptr)
+		assert.NoError(err, "test %d", i)
+		assert.JSONEq(string(expected), string(doc), "test %d", i)
+		fmt.Println("--- PASS")
+	}
+
+	doc := []byte(`{"only": [1]}`)
+	err := jsonpointer.Delete(&doc, "/only/0", jsonpointer.WithPreserveFormat(true))
+	assert.NoError(err)
+	assert.Equal(`{"only": []}`, string(doc))
+	err = jsonpointer.Delete(&doc, "/only", jsonpointer.WithPreserveFormat(true))
+	assert.NoError(err)
+	assert.Equal(`{}`, string(doc))
+}
+
+func TestPreserveFormatLayout(t *testing.T) {
+	assert := require.New(t)
+	opt := jsonpointer.WithPreserveFormat(true)
+
+	compact := []byte(`{"a":{"b":1}}`)
+	err := jsonpointer.Assign(&compact, "/a/c", []int{2})
+	assert.NoError(err)
+	// without the option, the document is re-encoded
+	assert.Equal(`{"a":{"b":1,"c":[2]}}`, string(compact))
+
+	compact = []byte(`{"z":1, "a":{}}`)
+	err = jsonpointer.Assign(&compact, "/a/c", []int{2}, opt)
+	assert.NoError(err)
+	assert.Equal(`{"z":1, "a":{"c":[2]}}`, string(compact))
+
+	tabs := []byte("{\n\t\"a\": {\n\t\t\"b\": 1\n\t}\n}")
+	err = jsonpointer.Assign(&tabs, "/a/c", map[string]int{"d": 2}, opt)
+	assert.NoError(err)
+	assert.Equal("{\n\t\"a\": {\n\t\t\"b\": 1,\n\t\t\"c\": {\n\t\t\t\"d\": 2\n\t\t}\n\t}\n}", string(tabs))
+
+	// the source is not modified
+	src := []byte(`{"a": 1, "b": 2}`)
+	doc := src
+	err = jsonpointer.Assign(&doc, "/a", 3, opt)
+	assert.NoError(err)
+	assert.Equal(`{"a": 3, "b": 2}`, string(doc))
+	assert.Equal(`{"a": 1, "b": 2}`, string(src))
+
+	r := Root{Nested: Nested{JSON: json.RawMessage(`{ "keep" : "as is",  "n": 1 }`)}}
+	err = jsonpointer.Assign(&r, "/nested/json/n", 2, opt)
+	assert.NoError(err)
+	assert.Equal(`{ "keep" : "as is",  "n": 2 }`, string(r.Nested.JSON))
+
+	// raw JSON replaces a value as it is
+	doc = []byte(`{"a": {"b": 1}, "c": 2}`)
+	err = jsonpointer.Assign(&doc, "/a", json.RawMessage(`{ "html":  "<b>" }`), opt)
+	assert.NoError(err)
+	assert.Equal(`{"a": { "html":  "<b>" }, "c": 2}`, string(doc))
+
+	// edits which can not be spliced are made by re-encoding
+	doc = []byte(`{"a": null, "b": [1]}`)
+	expected := append([]byte(nil), doc...)
+	err = jsonpointer.Assign(&doc, "/a/b", 1, opt)
+	assert.NoError(err)
+	err = jsonpointer.Assign(&expected, "/a/b", 1)
+	assert.NoError(err)
+	assert.Equal(string(expected), string(doc))
+
+	doc = []byte(`{"b": [1]}`)
+	err = jsonpointer.Assign(&doc, "/b/5", 1, opt)
+	assert.ErrorIs(err, jsonpointer.ErrOutOfRange)
+
+	doc = []byte(`{"a": {}}`)
+	err = jsonpointer.Assign(&doc, "/x/y", 1, opt, jsonpointer.WithCreateContainers(false))
+	assert.ErrorIs(err, jsonpointer.ErrUnreachable)
+	assert.Equal(`{"a": {}}`, string(doc))
+
+	// Assigner and Deleter are called rather than splicing
+	var hooked struct {
+		Doc hookedDoc `json:"doc"`
+	}
+	for _, opts := range [][]jsonpointer.Option{{opt}, nil} {
+		hooked.Doc = hookedDoc(`{"a": "x"}`)
+		err = jsonpointer.Assign(&hooked, "/doc/a", "y", opts...)
+		assert.NoError(err)
+		assert.Equal("assigned y at /a", string(hooked.Doc))
+
+		hooked.Doc = hookedDoc(`{"a": "x"}`)
+		err = jsonpointer.Delete(&hooked, "/doc/a", opts...)
+		assert.NoError(err)
+		assert.Equal("deleted /a", string(hooked.Doc))
+
+		hd := hookedDoc(`{"a": "x"}`)
+		err = jsonpointer.Assign(&hd, "/a", "y", opts...)
+		assert.NoError(err)
+		assert.Equal("assigned y at /a", string(hd))
+	}
+
+	// invalid JSON is reported as it is without the option
+	for _, ptr := range []jsonpointer.Pointer{"/a", "/c"} {
+		doc = []byte(`{"a":1,"b":tru}`)
+		expectedErr := jsonpointer.Assign(&doc, ptr, 2)
+		assert.Error(expectedErr)
+		err = jsonpointer.Assign(&doc, ptr, 2, opt)
+		assert.Equal(expectedErr.Error(), err.Error())
+		assert.Equal(`{"a":1,"b":tru}`, string(doc))
+
+		expectedErr = jsonpointer.Delete(&doc, ptr)
+		assert.Error(expectedErr)
+		err = jsonpointer.Delete(&doc, ptr, opt)
+		assert.Equal(expectedErr.Error(), err.Error())
+		assert.Equal(`{"a":1,"b":tru}`, string(doc))
+	}
+}
@@ -14,6 +14,8 @@
 package jsonpointer
 
 import (
+	"bytes"
+	"context"
 	"encoding"
 	"encoding/json"
 	"errors"
@@ -28,16 +30,20 @@ var (
 	// valinfoPool         sync.Pool
 	statePool           sync.Pool
 	typeAssigner        = reflect.TypeOf((*Assigner)(nil)).Elem()
+	typeResolver        = reflect.TypeOf((*Resolver)(nil)).Elem()
+	typeContextResolver = reflect.TypeOf((*ContextResolver)(nil)).Elem()
 	typeByteSlice       = reflect.TypeOf([]byte{})
+	typeRawMessage      = reflect.TypeOf(json.RawMessage{})
 	typeReader          = reflect.TypeOf((*io.Reader)(nil)).Elem()
 	typeWriter          = reflect.TypeOf((*io.Writer)(nil)).Elem()
 	typeTextUnmarshaler = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	typeTextMarshaler   = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
 	typeAny             = reflect.TypeOf((*interface{})(nil)).Elem()
 	typeAnySlice        = reflect.TypeOf([]interface{}{})
 	typeAnyMap          = reflect.TypeOf(map[string]interface{}{})
 )
 
-func newState(ptr Pointer, op Operation) *state {
+func newState(ptr Pointer, op Operation, opts Options) *state {
 	var s *state
 	if v := statePool.Get(); v != nil {
 		s = v.(*state)
@@ -47,6 +53,9 @@ func newState(ptr Pointer, op Operation) *state {
 	s.ptr = ptr
 	s.current = ptr
 	s.op = op
+	s.opts = opts
+	s.ctx = context.Background()
+	s.scan = false
 	return s
 }
 
@@ -54,9 +63,16 @@ type state struct {
 	op      Operation
 	ptr     Pointer
 	current Pointer
+	opts    Options
+	ctx     context.Context
+	// scan indicates that raw JSON may be resolved by scanning for the
+	// referenced value rather than decoding the whole of it. It is only set
+	// by operations which pass the resolved value to setResolved.
+	scan bool
 }
 
 func (s *state) Release() {
+	s.ctx = nil
 	statePool.Put(s)
 }
 
@@ -65,41 +81,48 @@ func (s state) Operation() Operation {
 }
 
 func (s *state) resolve(v reflect.Value) (reflect.Value, error) {
-	var t Token
 	var err error
-	var ok bool
 	for {
-		cur := s.current
-		if cur.IsRoot() {
+		if s.current.IsRoot() {
 			return v, nil
 		}
-		typ := v.Type()
-		if s.current, t, ok = cur.Next(); !ok {
-			return reflect.Value{}, fmt.Errorf("unexpected end of JSON pointer %v", s.current)
-		}
-		if err != nil {
-			return v, newError(err, *s, typ)
+		if v, err = s.resolveStep(v); err != nil {
+			return v, err
 		}
+	}
+}
 
-		v, err = s.resolveNext(v, t)
-		if err == nil {
-			switch v.Kind() {
-			case reflect.Interface, reflect.Ptr, reflect.Map, reflect.Slice:
-				if v.IsNil() && !cur.IsRoot() {
-					return v, newError(ErrUnreachable, *s, typ)
-				}
+// resolveStep resolves the next token of s.current from v, advancing
+// s.current. A Resolver may advance s.current by more than one token.
+func (s *state) resolveStep(v reflect.Value) (reflect.Value, error) {
+	var t Token
+	var err error
+	var ok bool
+	cur := s.current
+	typ := v.Type()
+	if s.current, t, ok = cur.Next(); !ok {
+		return reflect.Value{}, fmt.Errorf("unexpected end of JSON pointer %v", s.current)
+	}
+
+	v, err = s.resolveNext(v, t)
+	if err == nil {
+		switch v.Kind() {
+		case reflect.Interface, reflect.Ptr, reflect.Map, reflect.Slice:
+			if v.IsNil() && !cur.IsRoot() {
+				return v, newError(ErrUnreachable, *s, typ)
 			}
 		}
+	}
 
-		if err == nil && v.Kind() == reflect.Invalid {
-			err = newError(ErrNotFound, *s, typ)
-		}
-		if err != nil {
-			s.current = cur.Prepend(t)
-			updateErrorState(err, *s)
-			return v, err
-		}
+	if err == nil && v.Kind() == reflect.Invalid {
+		err = newError(ErrNotFound, *s, typ)
+	}
+	if err != nil {
+		s.current = cur
+		updateErrorState(err, *s)
+		return v, err
 	}
+	return v, nil
 }
 
 func (s *state) assign(dst reflect.Value, val reflect.Value) (reflect.Value, error) {
@@ -117,16 +140,23 @@ func (s *state) assign(dst reflect.Value, val reflect.Value) (reflect.Value, err
 	if !ok {
 		return reflect.Value{}, fmt.Errorf("unexpected end of JSON pointer %v", cur)
 	}
+	if s.opts.PreserveFormat && isRawDocument(dst.Elem()) && s.spliceAssign(dst, cur, val) {
+		return dst, nil
+	}
 	var cpy reflect.Value
 
 	if isByteSlice(dst.Elem()) {
 		cpy = dst
 		if dst.Elem().Len() > 0 {
-			dst, err = s.unmarshal(dst.Elem())
+			dst, err = s.unmarshalDocument(dst.Elem())
 			if err != nil {
 				return reflect.Value{}, err
 			}
 		} else {
+			if s.opts.NoCreate {
+				s.current = cur
+				return reflect.Value{}, newError(ErrUnreachable, *s, dst.Elem().Type())
+			}
 			_, nt, ok := s.current.Next()
 			if !ok {
 				return reflect.Value{}, newError(ErrMalformedToken, *s, dst.Type())
@@ -134,7 +164,7 @@ func (s *state) assign(dst reflect.Value, val reflect.Value) (reflect.Value, err
 			if s.current.IsRoot() {
 				dst = reflect.Zero(val.Type())
 			} else {
-				if _, err = nt.Index(0); err == nil {
+				if _, err = s.index(nt, 0); err == nil {
 					dst = reflect.MakeSlice(typeAnySlice, 0, 1)
 				} else {
 					dst = reflect.MakeMap(typeAnyMap)
@@ -151,8 +181,14 @@ func (s *state) assign(dst reflect.Value, val reflect.Value) (reflect.Value, err
 
 	rn, err = s.resolveNext(dst, t)
 	if err != nil {
+		s.current = cur
+		updateErrorState(err, *s)
 		return rn, err
 	}
+	if s.opts.NoCreate && !s.current.IsRoot() && isNil(rn) {
+		s.current = cur
+		return reflect.Value{}, newError(ErrUnreachable, *s, dst.Elem().Type())
+	}
 
 	switch rn.Kind() {
 	case reflect.Interface:
@@ -182,7 +218,7 @@ func (s *state) assign(dst reflect.Value, val reflect.Value) (reflect.Value, err
 				if !ok {
 					rn = reflect.Zero(val.Type())
 				} else {
-					if _, err = nt.Index(0); err == nil {
+					if _, err = s.index(nt, 0); err == nil {
 						rn = reflect.MakeSlice(typeAnySlice, 0, 1)
 					} else {
 						rn = reflect.MakeMap(typeAnyMap)
@@ -198,7 +234,7 @@ func (s *state) assign(dst reflect.Value, val reflect.Value) (reflect.Value, err
 				if s.current.IsRoot() {
 					rn = reflect.Zero(val.Type())
 				} else {
-					if _, err = nt.Index(0); err == nil {
+					if _, err = s.index(nt, 0); err == nil {
 						rn = reflect.MakeSlice(typeAnySlice, 0, 1)
 					} else {
 						rn = reflect.MakeMap(typeAnyMap)
@@ -225,7 +261,7 @@ func (s *state) assign(dst reflect.Value, val reflect.Value) (reflect.Value, err
 
 	s.current = s.current.Prepend(t)
 	if cpy.IsValid() {
-		if assigner, ok := asAssigner(cpy); ok {
+		if assigner, ok := s.asAssigner(cpy); ok {
 			err = assigner.AssignByJSONPointer(&cur, nv.Elem().Interface())
 			if err != nil {
 				if !errors.Is(err, YieldOperation) {
@@ -234,13 +270,14 @@ func (s *state) assign(dst reflect.Value, val reflect.Value) (reflect.Value, err
 					cur = s.current
 				}
 			} else {
-				return dst, nil
+				// the raw JSON, as set by assigner
+				return cpy, nil
 			}
 			// updating state to reflect the new token if it was set by assigner
 			s.current = cur
 		}
 	}
-	if assigner, ok := asAssigner(dst); ok {
+	if assigner, ok := s.asAssigner(dst); ok {
 		err = assigner.AssignByJSONPointer(&cur, nv.Elem().Interface())
 		if err != nil {
 			if !errors.Is(err, YieldOperation) {
@@ -294,12 +331,15 @@ func (s *state) delete(dst reflect.Value) (reflect.Value, error) {
 	if !ok {
 		return reflect.Value{}, fmt.Errorf("unexpected end of JSON pointer %v", cur)
 	}
+	if s.opts.PreserveFormat && isRawDocument(dst.Elem()) && s.spliceDelete(dst, cur) {
+		return dst, nil
+	}
 	var cpy reflect.Value
 
 	if isByteSlice(dst.Elem()) {
 		cpy = dst
 		if dst.Elem().Len() > 0 {
-			dst, err = s.unmarshal(dst.Elem())
+			dst, err = s.unmarshalDocument(dst.Elem())
 			if err != nil {
 				return reflect.Value{}, err
 			}
@@ -311,7 +351,7 @@ func (s *state) delete(dst reflect.Value) (reflect.Value, error) {
 			if s.current.IsRoot() {
 				dst.SetBytes([]byte{})
 			} else {
-				if _, err = nt.Index(0); err == nil {
+				if _, err = s.index(nt, 0); err == nil {
 					dst = reflect.MakeSlice(typeAnySlice, 0, 1)
 				} else {
 					dst = reflect.MakeMap(typeAnyMap)
@@ -324,7 +364,7 @@ func (s *state) delete(dst reflect.Value) (reflect.Value, error) {
 	}
 
 	if cpy.IsValid() {
-		if deleter, ok := asDeleter(cpy); ok {
+		if deleter, ok := s.asDeleter(cpy); ok {
 			err = deleter.DeleteByJSONPointer(&cur)
 			if err != nil {
 				if !errors.Is(err, YieldOperation) {
@@ -333,12 +373,13 @@ func (s *state) delete(dst reflect.Value) (reflect.Value, error) {
 					cur = s.current
 				}
 			} else {
-				return dst, nil
+				// the raw JSON, as set by deleter
+				return cpy, nil
 			}
 			s.current = cur
 		}
 	}
-	if deleter, ok := asDeleter(dst); ok {
+	if deleter, ok := s.asDeleter(dst); ok {
 		err = deleter.DeleteByJSONPointer(&cur)
 		if err != nil {
 			if !errors.Is(err, YieldOperation) {
@@ -355,8 +396,9 @@ func (s *state) delete(dst reflect.Value) (reflect.Value, error) {
 	// new dst
 	var rn reflect.Value
 	rn, err = s.resolveNext(dst, t)
-	// cur = s.current
 	if err != nil {
+		s.current = s.current.Prepend(t)
+		updateErrorState(err, *s)
 		return rn, err
 	}
 
@@ -375,9 +417,10 @@ func (s *state) delete(dst reflect.Value) (reflect.Value, error) {
 	case reflect.Ptr:
 		if rn.IsNil() {
 			s.current = ""
-		} else if s.current.IsRoot() {
+		} else if s.current.IsRoot() && rn.CanSet() {
+			// map elements are not settable; they are removed below
 			s.current = ""
-			rn.Set(reflect.ValueOf(nil))
+			rn.Set(reflect.Zero(rn.Type()))
 		}
 	case reflect.Map:
 		switch {
@@ -391,18 +434,18 @@ func (s *state) delete(dst reflect.Value) (reflect.Value, error) {
 			}
 		}
 	case reflect.Slice:
-		switch {
-		case rn.IsNil():
-			s.current = ""
-		case s.current.IsRoot():
-			err = s.deleteSliceIndex(rn, t)
-			if err != nil {
-				return reflect.Value{}, err
-			}
+		// a slice referenced by the final token is removed from dst below
+		if rn.IsNil() {
 			s.current = ""
 		}
 	case reflect.Invalid:
-		s.current = ""
+		// nothing to delete; s.current is left non-root so that the parent
+		// is not removed in its place
+		s.current = cur
+		if cpy.IsValid() {
+			// raw JSON is left as it was
+			return cpy, nil
+		}
 		return dst, nil
 	}
 	if rn.CanAddr() {
@@ -461,12 +504,32 @@ func (s *state) setValue(dst reflect.Value, v reflect.Value) error {
 		}
 		return s.setValue(dst.Elem(), v)
 	case reflect.Ptr:
-		if v.Type().AssignableTo(dst.Type().Elem()) {
+		et := dst.Type().Elem()
+		if v.Type().AssignableTo(et) {
 			dst.Elem().Set(v)
 			return nil
 		}
-		// none of the above is true
-		return newValueError(ErrNotAssignable, *s, dst.Type(), v.Type())
+		// values decoded from JSON are held by interface{}
+		for (v.Kind() == reflect.Interface || v.Kind() == reflect.Ptr) && !v.IsNil() {
+			if v = v.Elem(); v.Type().AssignableTo(et) {
+				dst.Elem().Set(v)
+				return nil
+			}
+		}
+		if et.Kind() == reflect.Ptr {
+			pv := reflect.New(et.Elem())
+			if err := s.setValue(pv, v); err != nil {
+				return err
+			}
+			dst.Elem().Set(pv)
+			return nil
+		}
+		cv, err := s.convert(v, et)
+		if err != nil {
+			return err
+		}
+		dst.Elem().Set(cv)
+		return nil
 	default:
 		// this should never be reached
 		panic("can not assign to non-pointer")
@@ -474,7 +537,7 @@ func (s *state) setValue(dst reflect.Value, v reflect.Value) error {
 }
 
 func (s state) marshal(v reflect.Value) (reflect.Value, error) {
-	b, err := json.Marshal(v.Interface())
+	b, err := s.opts.codec().Marshal(v.Interface())
 	if err != nil {
 		return reflect.Value{}, newError(err, s, v.Type())
 	}
@@ -483,15 +546,51 @@ func (s state) marshal(v reflect.Value) (reflect.Value, error) {
 	return bv, nil
 }
 
+// decodeNumbers decodes data into v with the Codec of s. With encoding/json,
+// numbers are decoded as json.Number.
+func (s state) decodeNumbers(data []byte, v interface{}) error {
+	if s.opts.Codec != nil {
+		return s.opts.codec().Unmarshal(data, v)
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(v); err != nil {
+		return err
+	}
+	if len(bytes.TrimSpace(data[dec.InputOffset():])) > 0 {
+		// reporting the error for trailing data as json.Unmarshal would
+		return json.Unmarshal(data, v)
+	}
+	return nil
+}
+
 func (s state) unmarshal(v reflect.Value) (reflect.Value, error) {
+	return s.unmarshalWith(v, s.opts.codec().Unmarshal)
+}
+
+// unmarshalDocument is unmarshal for raw JSON which is being assigned to or
+// deleted from. Numbers are decoded as json.Number so that those which are
+// not modified are encoded exactly as they were, rather than as a float64.
+func (s state) unmarshalDocument(v reflect.Value) (reflect.Value, error) {
+	return s.unmarshalWith(v, s.decodeNumbers)
+}
+
+func (s state) unmarshalWith(v reflect.Value, decode func([]byte, interface{}) error) (reflect.Value, error) {
 	var i interface{}
+	if v.Kind() == reflect.Interface {
+		v = v.Elem()
+	}
 	if len(v.Bytes()) == 0 {
 		return reflect.Value{}, nil
 	}
-	err := json.Unmarshal(v.Bytes(), &i)
+	err := decode(v.Bytes(), &i)
 	if err != nil {
 		return v, newError(err, s, reflect.TypeOf(v))
 	}
+	if i == nil {
+		// null, which is resolved as a nil interface{}
+		return reflect.New(typeAny), nil
+	}
 	iv := reflect.ValueOf(i)
 	ptr := reflect.New(iv.Type())
 	ptr.Elem().Set(iv)
@@ -500,24 +599,32 @@ func (s state) unmarshal(v reflect.Value) (reflect.Value, error) {
 
 func (s *state) resolveNext(v reflect.Value, t Token) (reflect.Value, error) {
 	var err error
-	switch {
-	case v.Type().NumMethod() > 0 && v.CanInterface():
-		if resolver, ok := v.Interface().(Resolver); ok {
-			rv, err := s.resolveResolver(resolver, v, t)
-			if err != nil {
-				if !errors.Is(err, YieldOperation) {
-					return rv, err
-				}
-			} else {
-				return rv, nil
+	if err = s.ctx.Err(); err != nil {
+		return reflect.Value{}, newError(err, *s, v.Type())
+	}
+	if resolver, ok := s.asResolver(v); ok {
+		rv, err := s.resolveResolver(resolver, v, t)
+		if err != nil {
+			if !errors.Is(err, YieldOperation) {
+				return rv, err
 			}
+		} else {
+			return rv, nil
 		}
+	}
+	switch {
 	case isByteSlice(v):
+		if rv, ok := s.scanRaw(v, t); ok {
+			return rv, nil
+		}
 		v, err = s.unmarshal(v)
 		if err != nil {
 			return v, err
 		}
 	case v.Kind() == reflect.Ptr && isByteSlice(v.Elem()):
+		if rv, ok := s.scanRaw(v.Elem(), t); ok {
+			return rv, nil
+		}
 		v, err = s.unmarshal(v.Elem())
 		if err != nil {
 			return v, err
@@ -584,11 +691,22 @@ func (s state) resolveArrayIndex(v reflect.Value, t Token) (reflect.Value, error
 }
 
 func (s state) resolveStructField(v reflect.Value, t Token) (reflect.Value, error) {
+	f, err := s.structField(v.Type(), t)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	return v.FieldByIndex(f.index), nil
+}
+
+// structField returns the field of the struct type typ which is named by t,
+// in accordance with s.opts. ErrReadOnly is returned if the field is readonly
+// and s is not resolving.
+func (s state) structField(typ reflect.Type, t Token) (*field, error) {
 	var fields structFields
-	if v.Type().Kind() == reflect.Ptr {
-		fields = cachedTypeFields(v.Type().Elem())
+	if typ.Kind() == reflect.Ptr {
+		fields = cachedTypeFields(typ.Elem(), s.opts.tagKeys())
 	} else {
-		fields = cachedTypeFields(v.Type())
+		fields = cachedTypeFields(typ, s.opts.tagKeys())
 	}
 
 	var f *field
@@ -602,16 +720,32 @@ func (s state) resolveStructField(v reflect.Value, t Token) (reflect.Value, erro
 				break
 			}
 		}
+		if f != nil && s.opts.CaseSensitive {
+			// the field is suggested rather than resolved
+			return nil, &fieldError{
+				ptrError:   *newError(ErrNotFound, s, typ),
+				field:      typ.FieldByIndex(f.index),
+				suggestion: Token(Encode(f.name)),
+			}
+		}
 	}
 	if f == nil {
-		fv, ok := v.Type().FieldByName(t.String())
+		fv, ok := typ.FieldByName(t.String())
 		if ok && !fv.IsExported() {
-			return reflect.Value{}, newError(ErrUnexportedField, s, v.Type())
+			return nil, &fieldError{
+				ptrError: *newError(ErrUnexportedField, s, typ),
+				field:    fv,
+			}
 		}
-		return reflect.Value{}, newError(ErrNotFound, s, v.Type())
+		return nil, newError(ErrNotFound, s, typ)
 	}
-
-	return v.FieldByIndex(f.index), nil
+	if f.readonly && !s.op.IsResolving() {
+		return nil, &fieldError{
+			ptrError: *newError(ErrReadOnly, s, typ),
+			field:    typ.FieldByIndex(f.index),
+		}
+	}
+	return f, nil
 }
 
 func (s state) resolveSlice(v reflect.Value, t Token) (reflect.Value, error) {
@@ -656,18 +790,22 @@ func (s *state) assignValue(dst reflect.Value, val reflect.Value) (reflect.Value
 	case val.Type().AssignableTo(dst.Elem().Type()):
 		dst.Elem().Set(val)
 		return dst, nil
-	case isByteSlice(val.Elem()):
-		err := json.Unmarshal(val.Elem().Bytes(), dst.Interface())
+	case isByteSlice(val):
+		if val.Kind() == reflect.Interface {
+			val = val.Elem()
+		}
+		err := s.opts.codec().Unmarshal(val.Bytes(), dst.Interface())
 		if err != nil {
 			return dst, newValueError(ErrNotAssignable, *s, dst.Type(), val.Type())
 		}
 		return dst, nil
 	case isByteSlice(dst.Elem()):
-		b, err := json.Marshal(val.Interface())
+		b, err := s.opts.codec().Marshal(val.Interface())
 		if err != nil {
 			return dst, newValueError(ErrNotAssignable, *s, dst.Type(), val.Type())
 		}
 		dst.Elem().SetBytes(b)
+		return dst, nil
 	}
 	return val, newValueError(ErrNotAssignable, *s, dst.Elem().Type(), val.Type())
 }
@@ -681,12 +819,30 @@ func (s *state) deleteValue(dst reflect.Value) error {
 	return nil
 }
 
+// index parses t as an index with next as the upper bound, in accordance with
+// s.opts.StrictIndex.
+func (s state) index(t Token, next int) (int, error) {
+	if s.opts.StrictIndex {
+		return t.StrictIndex(next)
+	}
+	return t.Index(next)
+}
+
 func (s state) sliceIndex(src reflect.Value, t Token) (int, error) {
-	i, err := t.Index(src.Len())
+	i, err := s.index(t, src.Len())
 	return i, err
 }
 
 func (s state) arrayIndex(src reflect.Value, t Token) (int, error) {
+	// arrays can not be appended to, so "-" is always out of range when
+	// StrictDash is enabled
+	if t == "-" && s.opts.StrictDash {
+		return -1, newError(&indexError{
+			err:      ErrOutOfRange,
+			maxIndex: src.Len() - 1,
+			index:    src.Len(),
+		}, s, src.Type())
+	}
 	// if t == "-" then we attempt to get the last non-zero index
 	z := -1
 	if t == "-" {
@@ -706,7 +862,7 @@ func (s state) arrayIndex(src reflect.Value, t Token) (int, error) {
 		return z, nil
 	}
 
-	z, err := t.Index(src.Type().Len() - 1)
+	z, err := s.index(t, src.Type().Len()-1)
 	if err != nil {
 		return z, newError(err, s, src.Type())
 	}
@@ -795,7 +951,7 @@ func (s *state) deleteSliceIndex(l reflect.Value, token Token) error {
 
 	reflect.Copy(e.Slice(i, e.Len()), e.Slice(i+1, e.Len()))
 
-	e.Index(e.Len() - 1).Set(reflect.Zero(e.Type()))
+	e.Index(e.Len() - 1).Set(reflect.Zero(e.Type().Elem()))
 	e.SetLen(e.Len() - 1)
 	l.Elem().Set(e)
 
@@ -843,28 +999,77 @@ func isByteSlice(v reflect.Value) bool {
 	return v.Type().AssignableTo(typeByteSlice)
 }
 
-func asAssigner(v reflect.Value) (Assigner, bool) {
+// asResolver returns v as a Resolver, preferring ContextResolver if v
+// implements it.
+func (s *state) asResolver(v reflect.Value) (Resolver, bool) {
+	if v.Type().NumMethod() == 0 || !v.CanInterface() {
+		return nil, false
+	}
+	switch r := v.Interface().(type) {
+	case ContextResolver:
+		return contextResolver{ctx: s.ctx, r: r}, true
+	case Resolver:
+		return r, true
+	}
+	return nil, false
+}
+
+func (s *state) asAssigner(v reflect.Value) (Assigner, bool) {
 	if v.Type().NumMethod() > 0 && v.CanInterface() {
-		as, ok := v.Interface().(Assigner)
-		if ok {
+		switch as := v.Interface().(type) {
+		case ContextAssigner:
+			return contextAssigner{ctx: s.ctx, a: as}, true
+		case Assigner:
 			return as, true
 		}
 	}
 	if v.Kind() == reflect.Ptr {
-		return asAssigner(v.Elem())
+		return s.asAssigner(v.Elem())
 	}
 	return nil, false
 }
 
-func asDeleter(v reflect.Value) (Deleter, bool) {
+func (s *state) asDeleter(v reflect.Value) (Deleter, bool) {
 	if v.Type().NumMethod() > 0 && v.CanInterface() {
-		del, ok := v.Interface().(Deleter)
-		if ok {
+		switch del := v.Interface().(type) {
+		case ContextDeleter:
+			return contextDeleter{ctx: s.ctx, d: del}, true
+		case Deleter:
 			return del, true
 		}
 	}
 	if v.Kind() == reflect.Ptr {
-		return asDeleter(v.Elem())
+		return s.asDeleter(v.Elem())
 	}
 	return nil, false
 }
+
+// contextResolver adapts a ContextResolver to a Resolver.
+type contextResolver struct {
+	ctx context.Context
+	r   ContextResolver
+}
+
+func (cr contextResolver) ResolveJSONPointer(ptr *Pointer, op Operation) (interface{}, error) {
+	return cr.r.ResolveJSONPointerContext(cr.ctx, ptr, op)
+}
+
+// contextAssigner adapts a ContextAssigner to an Assigner.
+type contextAssigner struct {
+	ctx context.Context
+	a   ContextAssigner
+}
+
+func (ca contextAssigner) AssignByJSONPointer(ptr *Pointer, value interface{}) error {
+	return ca.a.AssignByJSONPointerContext(ca.ctx, ptr, value)
+}
+
+// contextDeleter adapts a ContextDeleter to a Deleter.
+type contextDeleter struct {
+	ctx context.Context
+	d   ContextDeleter
+}
+
+func (cd contextDeleter) DeleteByJSONPointer(ptr *Pointer) error {
+	return cd.d.DeleteByJSONPointerContext(cd.ctx, ptr)
+}
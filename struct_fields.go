@@ -7,10 +7,19 @@ package jsonpointer
 import (
 	"reflect"
 	"sort"
+	"strings"
 	"sync"
 )
 
-var fieldCache sync.Map // map[reflect.Type]structFields
+var fieldCache sync.Map // map[fieldCacheKey]structFields
+
+// fieldCacheKey identifies the fields of a type for a given list of tag keys,
+// joined by commas.
+type fieldCacheKey struct {
+	typ reflect.Type
+	tag string
+}
+
 // A field represents a single field found in a struct.
 type field struct {
 	name      string
@@ -19,6 +28,8 @@ type field struct {
 	tag       bool
 	index     []int
 	typ       reflect.Type
+	readonly  bool
+	aliases   []string
 }
 
 type structFields struct {
@@ -27,18 +38,20 @@ type structFields struct {
 }
 
 // cachedTypeFields is like typeFields but uses a cache to avoid repeated work.
-func cachedTypeFields(t reflect.Type) structFields {
-	if f, ok := fieldCache.Load(t); ok {
+func cachedTypeFields(t reflect.Type, tagKeys []string) structFields {
+	key := fieldCacheKey{typ: t, tag: strings.Join(tagKeys, ",")}
+	if f, ok := fieldCache.Load(key); ok {
 		return f.(structFields)
 	}
-	f, _ := fieldCache.LoadOrStore(t, typeFields(t))
+	f, _ := fieldCache.LoadOrStore(key, typeFields(t, tagKeys))
 	return f.(structFields)
 }
 
-// typeFields returns a list of fields that JSON should recognize for the given type.
+// typeFields returns a list of fields that JSON should recognize for the given type,
+// named by the first of tagKeys which is present on each field.
 // The algorithm is breadth-first search over the set of structs to include - the top struct
 // and then any reachable anonymous structs.
-func typeFields(t reflect.Type) structFields {
+func typeFields(t reflect.Type, tagKeys []string) structFields {
 	// Anonymous fields to explore at the current level and the next.
 	current := []field{}
 	next := []field{{typ: t}}
@@ -80,12 +93,17 @@ func typeFields(t reflect.Type) structFields {
 					// Ignore unexported non-embedded fields.
 					continue
 				}
-				tag := sf.Tag.Get("json")
-				if tag == "-" {
+				pt := parsePointerTag(sf.Tag)
+				if pt.hidden {
 					continue
 				}
-
-				name, _ := parseTag(tag)
+				name := pt.name
+				if !isValidTag(name) {
+					var ok bool
+					if name, ok = lookupTagName(sf.Tag, tagKeys); !ok {
+						continue
+					}
+				}
 
 				if !isValidTag(name) {
 					name = ""
@@ -106,10 +124,12 @@ func typeFields(t reflect.Type) structFields {
 						name = sf.Name
 					}
 					field := field{
-						name:  name,
-						tag:   tagged,
-						index: index,
-						typ:   ft,
+						name:     name,
+						tag:      tagged,
+						index:    index,
+						typ:      ft,
+						readonly: pt.readonly,
+						aliases:  pt.aliases,
 					}
 					field.nameBytes = []byte(field.name)
 					field.equalFold = foldFunc(field.nameBytes)
@@ -190,6 +210,14 @@ func typeFields(t reflect.Type) structFields {
 	for i, field := range fields {
 		nameIndex[field.name] = i
 	}
+	// aliases never take precedence over the name of a field
+	for i, field := range fields {
+		for _, alias := range field.aliases {
+			if _, ok := nameIndex[alias]; !ok {
+				nameIndex[alias] = i
+			}
+		}
+	}
 	return structFields{fields, nameIndex}
 }
 
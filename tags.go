@@ -5,6 +5,7 @@
 package jsonpointer
 
 import (
+	"reflect"
 	"strings"
 	"unicode"
 )
@@ -22,6 +23,67 @@ func parseTag(tag string) (string, tagOptions) {
 	return tag, tagOptions("")
 }
 
+// lookupTagName returns the name of a struct field from the first of keys
+// which is present in tag and provides a name. If the first key present is
+// "-", ok is false as the field is to be ignored.
+func lookupTagName(tag reflect.StructTag, keys []string) (name string, ok bool) {
+	present := false
+	for _, key := range keys {
+		v, found := tag.Lookup(key)
+		if !found {
+			continue
+		}
+		if v == "-" {
+			if !present {
+				return "", false
+			}
+			continue
+		}
+		present = true
+		if name, _ = parseTag(v); name != "" {
+			return name, true
+		}
+	}
+	return "", true
+}
+
+// pointerTag is the parsed "jsonpointer" tag of a struct field:
+//
+//	`jsonpointer:"name,alias=oldName,alias=olderName,readonly"`
+//
+// A tag of "-" hides the field from JSON pointers.
+type pointerTag struct {
+	name     string
+	hidden   bool
+	readonly bool
+	aliases  []string
+}
+
+func parsePointerTag(tag reflect.StructTag) pointerTag {
+	v, ok := tag.Lookup("jsonpointer")
+	if !ok {
+		return pointerTag{}
+	}
+	if v == "-" {
+		return pointerTag{hidden: true}
+	}
+	name, opts := parseTag(v)
+	pt := pointerTag{name: name}
+	for opts != "" {
+		var opt string
+		opt, opts = parseTag(string(opts))
+		switch {
+		case opt == "readonly":
+			pt.readonly = true
+		case strings.HasPrefix(opt, "alias="):
+			if alias := strings.TrimPrefix(opt, "alias="); isValidTag(alias) {
+				pt.aliases = append(pt.aliases, alias)
+			}
+		}
+	}
+	return pt
+}
+
 func isValidTag(s string) bool {
 	if s == "" {
 		return false
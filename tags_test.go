@@ -0,0 +1,120 @@
+// Copyright 2022 Chance Dinkins
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+//
+// The License can be found in the LICENSE file.
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonpointer_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/chanced/jsonpointer"
+	"github.com/stretchr/testify/require"
+)
+
+type pointerTagged struct {
+	Name     string            `json:"name" jsonpointer:"displayName,alias=label,alias=title"`
+	ID       string            `json:"id" jsonpointer:",readonly"`
+	Meta     map[string]string `json:"meta" jsonpointer:",readonly"`
+	Password string            `json:"password" jsonpointer:"-"`
+	Dash     string            `json:"dash" jsonpointer:"-,"`
+	Title    string            `json:"title"`
+	Plain    string            `json:"plain"`
+}
+
+func TestPointerTag(t *testing.T) {
+	assert := require.New(t)
+	v := pointerTagged{
+		Name:     "name",
+		ID:       "id",
+		Meta:     map[string]string{"k": "v"},
+		Password: "secret",
+		Dash:     "dash",
+		Title:    "title",
+		Plain:    "plain",
+	}
+
+	tests := []struct {
+		ptr jsonpointer.Pointer
+		val string
+		err error
+	}{
+		{"/displayName", "name", nil},
+		{"/label", "name", nil},
+		// an alias never shadows the name of another field
+		{"/title", "title", nil},
+		{"/name", "", jsonpointer.ErrNotFound},
+		{"/id", "id", nil},
+		{"/meta/k", "v", nil},
+		{"/password", "", jsonpointer.ErrNotFound},
+		{"/-", "dash", nil},
+		{"/dash", "", jsonpointer.ErrNotFound},
+		{"/plain", "plain", nil},
+	}
+	for i, test := range tests {
+		fmt.Printf("=== RUN TestPointerTag #%d, pointer %s\n", i, test.ptr)
+		var s string
+		err := jsonpointer.Resolve(v, test.ptr, &s)
+		if test.err != nil {
+			assert.ErrorIs(err, test.err, "test %d", i)
+		} else {
+			assert.NoError(err, "test %d", i)
+		}
+		assert.Equal(test.val, s, "test %d", i)
+		fmt.Println("--- PASS")
+	}
+
+	// the jsonpointer tag takes precedence regardless of tag keys
+	var s string
+	err := jsonpointer.Resolve(v, "/displayName", &s, jsonpointer.WithTagKeys("yaml"))
+	assert.NoError(err)
+	assert.Equal("name", s)
+	err = jsonpointer.Resolve(v, "/password", &s, jsonpointer.WithTagKeys("yaml"))
+	assert.ErrorIs(err, jsonpointer.ErrNotFound)
+
+	err = jsonpointer.Assign(&v, "/label", "new name")
+	assert.NoError(err)
+	assert.Equal("new name", v.Name)
+}
+
+func TestPointerTagReadOnly(t *testing.T) {
+	assert := require.New(t)
+	v := pointerTagged{ID: "id", Meta: map[string]string{"k": "v"}}
+
+	tests := []struct {
+		ptr   jsonpointer.Pointer
+		field string
+	}{
+		{"/id", "ID"},
+		{"/meta", "Meta"},
+		{"/meta/k", "Meta"},
+	}
+	for i, test := range tests {
+		fmt.Printf("=== RUN TestPointerTagReadOnly #%d, pointer %s\n", i, test.ptr)
+		err := jsonpointer.Assign(&v, test.ptr, "x")
+		assert.ErrorIs(err, jsonpointer.ErrReadOnly, "test %d", i)
+		fe, ok := jsonpointer.AsFieldError(err)
+		assert.True(ok, "test %d", i)
+		assert.Equal(test.field, fe.Field().Name, "test %d", i)
+		assert.Equal(jsonpointer.Assigning, fe.Operation(), "test %d", i)
+
+		err = jsonpointer.Delete(&v, test.ptr)
+		assert.ErrorIs(err, jsonpointer.ErrReadOnly, "test %d", i)
+		fe, ok = jsonpointer.AsFieldError(err)
+		assert.True(ok, "test %d", i)
+		assert.Equal(test.field, fe.Field().Name, "test %d", i)
+		fmt.Println("--- PASS")
+	}
+	assert.Equal("id", v.ID)
+	assert.Equal(map[string]string{"k": "v"}, v.Meta)
+	assert.True(jsonpointer.Has(v, "/meta/k"))
+}
@@ -16,17 +16,28 @@ package jsonpointer
 import (
 	"fmt"
 	"strconv"
+	"strings"
 )
 
 // Decode decodes a JSON Pointer token by replacing each encoded slash ("~1")
 // with '/' (%x2F) and each encoded tilde ("~0") with '~' (%x7E).
+//
+// If token does not contain a '~', token is returned as is.
 func Decode(token string) string {
+	if strings.IndexByte(token, '~') == -1 {
+		return token
+	}
 	return decoder.Replace(token)
 }
 
 // Encode encodes a string to a token of a JSON Pointer by replacing each '~'
 // (%x7E) with "~0" and '/' (%x2F) with "~1".
+//
+// If token does not contain a '~' or '/', token is returned as is.
 func Encode(token string) string {
+	if strings.IndexAny(token, "~/") == -1 {
+		return token
+	}
 	return encoder.Replace(token)
 }
 
@@ -38,9 +49,10 @@ func (t Token) Bytes() []byte {
 	return []byte(t.String())
 }
 
-// String returns the decoded value of t
+// String returns the decoded value of t. If t does not contain any encoded
+// characters, the underlying string is returned without allocating.
 func (t Token) String() string {
-	return decoder.Replace(string(t))
+	return Decode(string(t))
 }
 
 // Int64 attempts to parse t as an int64. If t can be parsed as an int64 then
@@ -99,6 +111,71 @@ func (t Token) Index(next int) (int, error) {
 	return i, nil
 }
 
+// canonicalIndex parses t as an array index in accordance with the grammar of
+// RFC 6901:
+//
+//	array-index = %x30 / ( %x31-39 *(%x30-39) )
+func (t Token) canonicalIndex() (int, bool) {
+	if len(t) == 0 || (len(t) > 1 && t[0] == '0') {
+		return -1, false
+	}
+	for i := 0; i < len(t); i++ {
+		if t[i] < '0' || t[i] > '9' {
+			return -1, false
+		}
+	}
+	i, err := strconv.Atoi(string(t))
+	if err != nil {
+		return -1, false
+	}
+	return i, true
+}
+
+// StrictIndex is like Index, except that t must conform to the array-index
+// grammar of RFC 6901, either "0" or a digit 1-9 followed by any number of
+// digits. If t can be parsed as an int but is not in canonical form (e.g.
+// "+1", "01", "-0"), -1 and an IndexError wrapping ErrNonCanonicalIndex is
+// returned.
+//
+// next must be greater than or equal to 0.
+func (t Token) StrictIndex(next int) (int, error) {
+	if next < 0 {
+		return -1, fmt.Errorf("next (%d) must be greater than or equal to 0", next)
+	}
+	if t == "-" {
+		return next, nil
+	}
+	i, ok := t.canonicalIndex()
+	if !ok {
+		if n, err := t.Int(); err != nil || n < 0 {
+			return t.Index(next)
+		}
+		return -1, &indexError{
+			err:      ErrNonCanonicalIndex,
+			maxIndex: next,
+			index:    -1,
+		}
+	}
+	if i > next {
+		return -1, &indexError{
+			err:      ErrOutOfRange,
+			maxIndex: next,
+			index:    i,
+		}
+	}
+	return i, nil
+}
+
+// isNonCanonicalIndex returns true if t can be parsed as a non-negative int
+// but does not conform to the array-index grammar of RFC 6901.
+func (t Token) isNonCanonicalIndex() bool {
+	if _, ok := t.canonicalIndex(); ok {
+		return false
+	}
+	i, err := t.Int()
+	return err == nil && i >= 0
+}
+
 // Tokens is a slice of Tokens.
 type Tokens []Token
 
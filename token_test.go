@@ -14,6 +14,7 @@
 package jsonpointer_test
 
 import (
+	"strconv"
 	"testing"
 
 	"github.com/chanced/jsonpointer"
@@ -78,3 +79,64 @@ func TestTokenString(t *testing.T) {
 // 		fmt.Println("--- PASS TestTokenIsIndexable #", i)
 // 	}
 // }
+
+func TestDecodeEncode(t *testing.T) {
+	assert := require.New(t)
+	tests := []struct {
+		decoded string
+		encoded string
+	}{
+		{"", ""},
+		{"foo", "foo"},
+		{"foo/bar", "foo~1bar"},
+		{"~", "~0"},
+		{"~1", "~01"},
+		{"/~", "~1~0"},
+	}
+	for _, test := range tests {
+		assert.Equal(test.encoded, jsonpointer.Encode(test.decoded))
+		assert.Equal(test.decoded, jsonpointer.Decode(test.encoded))
+		assert.Equal(test.decoded, jsonpointer.Token(test.encoded).String())
+	}
+}
+
+func TestTokenStrictIndex(t *testing.T) {
+	assert := require.New(t)
+	tests := []struct {
+		token    jsonpointer.Token
+		next     int
+		expected int
+		err      error
+	}{
+		{"0", 3, 0, nil},
+		{"3", 3, 3, nil},
+		{"10", 10, 10, nil},
+		{"-", 3, 3, nil},
+		{"4", 3, -1, jsonpointer.ErrOutOfRange},
+		{"01", 3, -1, jsonpointer.ErrNonCanonicalIndex},
+		{"+1", 3, -1, jsonpointer.ErrNonCanonicalIndex},
+		{"-0", 3, -1, jsonpointer.ErrNonCanonicalIndex},
+		{"00", 3, -1, jsonpointer.ErrNonCanonicalIndex},
+		{"-1", 3, -1, jsonpointer.ErrOutOfRange},
+		{"a", 3, -1, strconv.ErrSyntax},
+		{"", 3, -1, strconv.ErrSyntax},
+	}
+	for _, test := range tests {
+		i, err := test.token.StrictIndex(test.next)
+		if test.err != nil {
+			assert.ErrorIs(err, test.err, "token %q", test.token)
+			_, ok := jsonpointer.AsIndexError(err)
+			assert.True(ok)
+		} else {
+			assert.NoError(err, "token %q", test.token)
+		}
+		assert.Equal(test.expected, i, "token %q", test.token)
+	}
+
+	_, err := jsonpointer.Token("01").StrictIndex(3)
+	assert.ErrorIs(err, jsonpointer.ErrMalformedIndex)
+
+	i, err := jsonpointer.Token("01").Index(3)
+	assert.NoError(err)
+	assert.Equal(1, i)
+}
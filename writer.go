@@ -0,0 +1,69 @@
+// Copyright 2022 Chance Dinkins
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+//
+// The License can be found in the LICENSE file.
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonpointer
+
+import (
+	"encoding/json"
+	"io"
+	"reflect"
+)
+
+// isWriter reports whether dv, the destination of a resolution, is a non-nil
+// io.Writer.
+func isWriter(dv reflect.Value) bool {
+	if !dv.IsValid() || !dv.Type().Implements(typeWriter) {
+		return false
+	}
+	return !isNil(dv)
+}
+
+// writesTo reports whether v, a resolved value, is to be written to dv as
+// JSON rather than assigned to it. A value which is assignable to the element
+// of a pointer to an io.Writer, such as a bytes.Buffer resolved into a
+// *bytes.Buffer, is assigned.
+func writesTo(dv reflect.Value, v reflect.Value) bool {
+	if !isWriter(dv) {
+		return false
+	}
+	return dv.Kind() != reflect.Ptr || !v.IsValid() || !v.Type().AssignableTo(dv.Type().Elem())
+}
+
+// writeResolved writes the JSON encoding of v to w. Raw JSON scanned from the
+// source is written as is, as is a json.RawMessage or []byte of valid JSON,
+// such as the root of a raw source, rather than being compacted or encoded in
+// base64.
+func (s *state) writeResolved(w io.Writer, v reflect.Value) error {
+	typ := reflect.TypeOf(w)
+	if v.IsValid() && v.Kind() == reflect.Interface && !v.IsNil() {
+		v = v.Elem()
+	}
+	var b []byte
+	switch {
+	case v.IsValid() && v.Type() == typeRawJSON:
+		b = v.Bytes()
+	case v.IsValid() && (v.Type() == typeRawMessage || v.Type() == typeByteSlice) && json.Valid(v.Bytes()):
+		b = v.Bytes()
+	case !v.IsValid() || !v.CanInterface():
+		b = []byte("null")
+	default:
+		var err error
+		if b, err = s.opts.codec().Marshal(v.Interface()); err != nil {
+			return newError(err, *s, typ)
+		}
+	}
+	if _, err := w.Write(b); err != nil {
+		return newError(err, *s, typ)
+	}
+	return nil
+}
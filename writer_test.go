@@ -0,0 +1,107 @@
+// Copyright 2022 Chance Dinkins
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+//
+// The License can be found in the LICENSE file.
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonpointer_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/chanced/jsonpointer"
+	"github.com/stretchr/testify/require"
+)
+
+type errWriter struct{ err error }
+
+func (w errWriter) Write(p []byte) (int, error) { return 0, w.err }
+
+func TestResolveWriter(t *testing.T) {
+	assert := require.New(t)
+
+	r := Root{Nested: Nested{Str: "strval", IntSlice: []int{1, 2}}}
+	var buf bytes.Buffer
+	err := jsonpointer.Resolve(r, "/nested/intslice", &buf)
+	assert.NoError(err)
+	assert.Equal(`[1,2]`, buf.String())
+
+	buf.Reset()
+	err = jsonpointer.Resolve(r, "/nested/str", &buf)
+	assert.NoError(err)
+	assert.Equal(`"strval"`, buf.String())
+
+	// raw JSON is written as is
+	doc := []byte(`{"a": {"b": [ 1, 2 ]}, "c": null}`)
+	buf.Reset()
+	err = jsonpointer.Resolve(doc, "/a", &buf)
+	assert.NoError(err)
+	assert.Equal(`{"b": [ 1, 2 ]}`, buf.String())
+
+	// including the root of the document and json.RawMessage values, which
+	// are neither encoded in base64 nor compacted
+	buf.Reset()
+	err = jsonpointer.Resolve(doc, "", &buf)
+	assert.NoError(err)
+	assert.Equal(string(doc), buf.String())
+
+	buf.Reset()
+	msg := map[string]interface{}{"msg": json.RawMessage(`{ "a": [ 1, 2 ] }`)}
+	err = jsonpointer.Resolve(msg, "/msg", &buf)
+	assert.NoError(err)
+	assert.Equal(`{ "a": [ 1, 2 ] }`, buf.String())
+
+	// byte slices which are not JSON are encoded
+	buf.Reset()
+	err = jsonpointer.Resolve(map[string][]byte{"bin": {0xff}}, "/bin", &buf)
+	assert.NoError(err)
+	assert.Equal(`"/w=="`, buf.String())
+
+	rec := httptest.NewRecorder()
+	err = jsonpointer.Resolve(strings.NewReader(string(doc)), "/a/b", rec)
+	assert.NoError(err)
+	assert.Equal(`[ 1, 2 ]`, rec.Body.String())
+
+	buf.Reset()
+	err = jsonpointer.Resolve(map[string]interface{}{"c": nil}, "", &buf)
+	assert.NoError(err)
+	assert.Equal(`{"c":null}`, buf.String())
+
+	buf.Reset()
+	err = jsonpointer.Resolve(doc, "/missing", &buf)
+	assert.ErrorIs(err, jsonpointer.ErrNotFound)
+	assert.Zero(buf.Len())
+
+	errW := errors.New("write failed")
+	err = jsonpointer.Resolve(doc, "/a", errWriter{errW})
+	assert.ErrorIs(err, errW)
+
+	// values assignable to the writer are assigned
+	var dst bytes.Buffer
+	src := map[string]bytes.Buffer{"buf": *bytes.NewBufferString("contents")}
+	err = jsonpointer.Resolve(src, "/buf", &dst)
+	assert.NoError(err)
+	assert.Equal("contents", dst.String())
+
+	var nilBuf *bytes.Buffer
+	err = jsonpointer.Resolve(doc, "/a", nilBuf)
+	assert.ErrorIs(err, jsonpointer.ErrNonPointer)
+
+	c, err := jsonpointer.CompileFor[Root]("/nested/str")
+	assert.NoError(err)
+	buf.Reset()
+	assert.NoError(c.Resolve(r, &buf))
+	assert.Equal(`"strval"`, buf.String())
+}